@@ -0,0 +1,103 @@
+package trie
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrEmptyWord is returned when a Batch holds a word that is empty once it is normalized
+var ErrEmptyWord = errors.New("trie: empty word")
+
+// BatchResult is what happened to one of the changes in a Batch
+type BatchResult int
+
+const (
+	// Inserted means the word was not in the Trie and was inserted
+	Inserted BatchResult = iota + 1
+
+	// AlreadyPresent means the word was already in the Trie, so inserting it changed nothing
+	AlreadyPresent
+
+	// Removed means the word was in the Trie and was removed
+	Removed
+
+	// NotFound means the word was not in the Trie, so removing it changed nothing
+	NotFound
+)
+
+// String returns the name of the result
+func (r BatchResult) String() string {
+	switch r {
+	case Inserted:
+		return "inserted"
+	case AlreadyPresent:
+		return "already present"
+	case Removed:
+		return "removed"
+	case NotFound:
+		return "not found"
+	default:
+		return fmt.Sprintf("BatchResult(%d)", int(r))
+	}
+}
+
+// Batch is a set of inserts and removes that Apply makes to a Trie all at once, in the order they were added
+// to the Batch. The zero value is an empty Batch ready to use.
+type Batch struct {
+	changes []change
+}
+
+// change is a word to insert or remove
+type change struct {
+	word   string
+	remove bool
+}
+
+// Insert adds inserting the word to the Batch
+func (b *Batch) Insert(word string) {
+	b.changes = append(b.changes, change{word: word})
+}
+
+// Remove adds removing the word to the Batch
+func (b *Batch) Remove(word string) {
+	b.changes = append(b.changes, change{word: word, remove: true})
+}
+
+// Len returns the number of changes in the Batch
+func (b *Batch) Len() int {
+	return len(b.changes)
+}
+
+// Apply makes every change in the Batch to the Trie at once, so searches see either none of the changes or all
+// of them. It returns what happened to each change, in the order they were added to the Batch. When any word in
+// the Batch is empty once it is normalized, Apply returns ErrEmptyWord and leaves the Trie unchanged.
+func (t *Trie) Apply(b *Batch) ([]BatchResult, error) {
+
+	words := make([][]rune, len(b.changes))
+	for i, c := range b.changes {
+		if words[i] = t.split(c.word); len(words[i]) == 0 {
+			return nil, fmt.Errorf("%w: change %v of %v", ErrEmptyWord, i+1, len(b.changes))
+		}
+	}
+
+	results := make([]BatchResult, len(b.changes))
+
+	t.update(func(s *Snapshot) {
+		for i, c := range b.changes {
+			switch {
+			case c.remove && s.delete(words[i]):
+				results[i] = Removed
+			case c.remove:
+				results[i] = NotFound
+			default:
+				if _, inserted := s.add(words[i], c.word); inserted {
+					results[i] = Inserted
+				} else {
+					results[i] = AlreadyPresent
+				}
+			}
+		}
+	})
+
+	return results, nil
+}
@@ -0,0 +1,85 @@
+package trie
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestApply(t *testing.T) {
+
+	trie := NewTrie()
+	trie.Insert("alpha")
+	trie.Insert("bravo")
+
+	var b Batch
+	b.Insert("charlie")
+	b.Insert("Alpha")
+	b.Remove("bravo")
+	b.Remove("delta")
+	b.Insert("delta")
+	b.Remove("charlie")
+
+	results, err := trie.Apply(&b)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []BatchResult{Inserted, AlreadyPresent, Removed, NotFound, Inserted, Removed}
+	if len(results) != len(expected) {
+		t.Fatalf("apply should return %v results; found %v", len(expected), len(results))
+	}
+
+	for i, r := range expected {
+		if results[i] != r {
+			t.Errorf("change %v should be %v; found %v", i, r, results[i])
+		}
+	}
+
+	verifyOrderedMatches(t, trie.Range("", "", -1), "alpha", "delta")
+
+	if trie.Count() != 2 {
+		t.Errorf("trie should hold 2 words; found %v", trie.Count())
+	}
+}
+
+func TestApplyIsAllOrNothing(t *testing.T) {
+
+	trie := NewTrie()
+	trie.Insert("alpha")
+
+	snapshot := trie.Snapshot()
+
+	var b Batch
+	b.Insert("bravo")
+	b.Remove("alpha")
+	b.Insert("")
+
+	if _, err := trie.Apply(&b); !errors.Is(err, ErrEmptyWord) {
+		t.Errorf("an empty word should return ErrEmptyWord; found %v", err)
+	}
+
+	if trie.Snapshot() != snapshot || !trie.Contains("alpha") || trie.Contains("bravo") {
+		t.Error("trie should be unchanged when a change is not valid")
+	}
+}
+
+func TestApplyEmptyBatch(t *testing.T) {
+
+	trie := NewTrie()
+
+	results, err := trie.Apply(&Batch{})
+	if err != nil || len(results) != 0 {
+		t.Errorf("an empty batch should have no results; found %v, %v", results, err)
+	}
+}
+
+func TestBatchResultString(t *testing.T) {
+
+	if s := AlreadyPresent.String(); s != "already present" {
+		t.Errorf("result should be 'already present'; found '%v'", s)
+	}
+
+	if s := BatchResult(0).String(); s != "BatchResult(0)" {
+		t.Errorf("unknown result should be 'BatchResult(0)'; found '%v'", s)
+	}
+}
@@ -0,0 +1,477 @@
+package trie
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"math"
+	"unicode/utf8"
+)
+
+// The binary format written by WriteTo is laid out as follows, where uvarint is
+// an unsigned varint as written by encoding/binary:
+//
+//	magic     4 bytes, "TRIE"
+//	version   1 byte
+//	normalize 4 bytes, big endian fingerprint of the Normalizer, see fingerprint
+//	count     uvarint, the number of words
+//	children  uvarint, the number of root nodes, followed by each node
+//	checksum  4 bytes, big endian CRC-32 (IEEE) of everything before it
+//
+// Each node is written before its children as:
+//
+//	length    uvarint, the number of bytes in the value
+//	value     the UTF-8 encoded runes of the edge leading to the node
+//	flags     1 byte, see flagEndOfWord, flagScore and flagForms
+//	score     8 bytes, little endian IEEE 754, only when flagScore is set
+//	forms     uvarint, the number of display forms, followed by each form,
+//	          only when flagForms is set
+//	children  uvarint, the number of children, followed by each child
+//
+// Each display form is written as:
+//
+//	length    uvarint, the number of bytes in the form
+//	text      the UTF-8 encoded form
+//	count     uvarint, the number of times the word was inserted as the form
+//
+// Version 1 of the format did not have display forms, and versions 1 and 2 did
+// not have the fingerprint of the Normalizer. Both are still read.
+const (
+	magic         = "TRIE"
+	formatVersion = 3
+
+	flagEndOfWord = 1 << 0
+	flagScore     = 1 << 1
+	flagForms     = 1 << 2
+)
+
+var (
+	// ErrFormat is returned when reading data that is not a valid Trie
+	ErrFormat = errors.New("trie: invalid format")
+
+	// ErrVersion is returned when reading a Trie written in an unsupported format version
+	ErrVersion = errors.New("trie: unsupported format version")
+
+	// ErrChecksum is returned when the data read does not match its checksum
+	ErrChecksum = errors.New("trie: checksum mismatch")
+
+	// ErrNormalizer is returned when reading a Trie written with a Normalizer that normalizes words differently
+	ErrNormalizer = errors.New("trie: written with a different Normalizer")
+)
+
+// normalizerProbe is normalized to tell Normalizers apart, so it mixes the
+// case, accents and compatibility runes that Normalizers commonly change
+const normalizerProbe = "Trie ÀÉÎõü ǅ ß İ ﬁ Ω １"
+
+// fingerprint identifies how the settings normalize words by the checksum of the normalized probe. Words
+// written by a Trie that normalizes differently may never be matched once they are read.
+func (c *settings) fingerprint() uint32 {
+	return crc32.ChecksumIEEE([]byte(c.normalize(normalizerProbe)))
+}
+
+// WriteTo writes the words in the Trie, along with their scores, to w in a compact binary format that ReadFrom
+// can load. It returns the number of bytes written.
+func (t *Trie) WriteTo(w io.Writer) (int64, error) {
+	return t.Snapshot().WriteTo(w)
+}
+
+// WriteTo writes the words in the Snapshot in the format written by Trie.WriteTo
+func (s *Snapshot) WriteTo(w io.Writer) (int64, error) {
+
+	e := newEncoder(w)
+
+	e.write([]byte(magic))
+	e.writeByte(formatVersion)
+	binary.BigEndian.PutUint32(e.buf[:4], s.fingerprint())
+	e.write(e.buf[:4])
+	e.uvarint(uint64(s.count))
+	e.nodes(s.children)
+
+	e.checksum()
+
+	return e.n, e.err
+}
+
+// ReadFrom replaces the contents of the Trie with the words read from r, which must have been written by WriteTo
+// from a Trie with the same Normalizer. It returns the number of bytes read. The Trie is left unchanged if an
+// error occurs.
+//
+// Nothing past the end of the Trie is read from r, so r may carry more data after it. Unless r is an
+// io.ByteReader it is read one byte at a time, so wrap a file in a bufio.Reader when reading it.
+func (t *Trie) ReadFrom(r io.Reader) (int64, error) {
+
+	d := newDecoder(r)
+
+	count, children, err := d.trie(&t.settings)
+	if err != nil {
+		return d.n, err
+	}
+
+	t.swap(count, children)
+
+	return d.n, nil
+}
+
+// encoder writes the binary format, keeping the first error it runs into
+type encoder struct {
+	w   *bufio.Writer
+	crc uint32
+	n   int64
+	err error
+	buf [binary.MaxVarintLen64]byte
+}
+
+func newEncoder(w io.Writer) *encoder {
+	return &encoder{w: bufio.NewWriter(w)}
+}
+
+func (e *encoder) write(p []byte) {
+	if e.err != nil {
+		return
+	}
+
+	n, err := e.w.Write(p)
+	e.crc = crc32.Update(e.crc, crc32.IEEETable, p[:n])
+	e.n += int64(n)
+	e.err = err
+}
+
+func (e *encoder) writeByte(b byte) {
+	e.buf[0] = b
+	e.write(e.buf[:1])
+}
+
+func (e *encoder) uvarint(v uint64) {
+	e.write(e.buf[:binary.PutUvarint(e.buf[:], v)])
+}
+
+func (e *encoder) nodes(nodes []*node) {
+
+	e.uvarint(uint64(len(nodes)))
+
+	for _, n := range nodes {
+
+		value := []byte(string(n.value))
+		e.uvarint(uint64(len(value)))
+		e.write(value)
+
+		var flags byte
+		if n.endOfWord {
+			flags |= flagEndOfWord
+		}
+		if n.score() != 0 {
+			flags |= flagScore
+		}
+		if len(n.forms()) > 0 {
+			flags |= flagForms
+		}
+		e.writeByte(flags)
+
+		if n.score() != 0 {
+			binary.LittleEndian.PutUint64(e.buf[:8], math.Float64bits(n.score()))
+			e.write(e.buf[:8])
+		}
+
+		if forms := n.forms(); len(forms) > 0 {
+			e.uvarint(uint64(len(forms)))
+			for _, f := range forms {
+				e.uvarint(uint64(len(f.text)))
+				e.write([]byte(f.text))
+				e.uvarint(uint64(f.count))
+			}
+		}
+
+		e.nodes(n.children)
+	}
+}
+
+// checksum writes the checksum of everything written so far and flushes the output
+func (e *encoder) checksum() {
+
+	binary.BigEndian.PutUint32(e.buf[:4], e.crc)
+	e.write(e.buf[:4])
+
+	if e.err == nil {
+		e.err = e.w.Flush()
+	}
+}
+
+// decoder reads the binary format, keeping track of the bytes read and their checksum
+type decoder struct {
+	r   io.ByteReader
+	crc uint32
+	n   int64
+	err error
+	one [1]byte
+	buf [8]byte
+}
+
+func newDecoder(r io.Reader) *decoder {
+	br, ok := r.(io.ByteReader)
+	if !ok {
+		br = &byteReader{r: r}
+	}
+
+	return &decoder{r: br}
+}
+
+// byteReader reads a single byte at a time from r, so that unlike a
+// bufio.Reader it never takes bytes from r that it does not return
+type byteReader struct {
+	r   io.Reader
+	buf [1]byte
+}
+
+func (b *byteReader) ReadByte() (byte, error) {
+	if _, err := io.ReadFull(b.r, b.buf[:]); err != nil {
+		return 0, err
+	}
+
+	return b.buf[0], nil
+}
+
+// ReadByte lets the decoder be used with binary.ReadUvarint
+func (d *decoder) ReadByte() (byte, error) {
+
+	b, err := d.r.ReadByte()
+	if err != nil {
+		d.err = unexpected(err)
+		return 0, d.err
+	}
+
+	d.one[0] = b
+	d.crc = crc32.Update(d.crc, crc32.IEEETable, d.one[:])
+	d.n++
+
+	return b, nil
+}
+
+func (d *decoder) read(p []byte) error {
+	for i := range p {
+		b, err := d.ReadByte()
+		if err != nil {
+			return err
+		}
+		p[i] = b
+	}
+
+	return nil
+}
+
+func (d *decoder) uvarint() (int, error) {
+
+	v, err := binary.ReadUvarint(d)
+	if d.err != nil {
+		return 0, d.err
+	}
+
+	if err != nil {
+		return 0, fmt.Errorf("%w: %v", ErrFormat, err)
+	}
+
+	if v > math.MaxInt32 {
+		return 0, fmt.Errorf("%w: length %v is too large", ErrFormat, v)
+	}
+
+	return int(v), nil
+}
+
+// trie reads a Trie written by a Trie with the supplied settings
+func (d *decoder) trie(c *settings) (int, []*node, error) {
+
+	header := make([]byte, len(magic)+1)
+	if err := d.read(header); err != nil {
+		return 0, nil, err
+	}
+
+	if string(header[:len(magic)]) != magic {
+		return 0, nil, fmt.Errorf("%w: missing header", ErrFormat)
+	}
+
+	version := header[len(magic)]
+	if version < 1 || version > formatVersion {
+		return 0, nil, fmt.Errorf("%w: %v", ErrVersion, version)
+	}
+
+	if version >= 3 {
+		if err := d.read(d.buf[:4]); err != nil {
+			return 0, nil, err
+		}
+
+		if binary.BigEndian.Uint32(d.buf[:4]) != c.fingerprint() {
+			return 0, nil, ErrNormalizer
+		}
+	}
+
+	count, err := d.uvarint()
+	if err != nil {
+		return 0, nil, err
+	}
+
+	words := 0
+	children, err := d.nodes(&words)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	if words != count {
+		return 0, nil, fmt.Errorf("%w: expected %v words but found %v", ErrFormat, count, words)
+	}
+
+	expected := d.crc
+	if err := d.read(d.buf[:4]); err != nil {
+		return 0, nil, err
+	}
+
+	if binary.BigEndian.Uint32(d.buf[:4]) != expected {
+		return 0, nil, ErrChecksum
+	}
+
+	return count, children, nil
+}
+
+func (d *decoder) nodes(words *int) ([]*node, error) {
+
+	length, err := d.uvarint()
+	if err != nil {
+		return nil, err
+	}
+
+	nodes := make([]*node, 0)
+	for i := 0; i < length; i++ {
+
+		n, err := d.node(words)
+		if err != nil {
+			return nil, err
+		}
+
+		if i > 0 && nodes[i-1].value[0] >= n.value[0] {
+			return nil, fmt.Errorf("%w: children are not sorted", ErrFormat)
+		}
+
+		nodes = append(nodes, n)
+	}
+
+	return nodes, nil
+}
+
+func (d *decoder) node(words *int) (*node, error) {
+
+	length, err := d.uvarint()
+	if err != nil {
+		return nil, err
+	}
+
+	value, err := d.text(length)
+	if err != nil {
+		return nil, err
+	}
+
+	if length == 0 {
+		return nil, fmt.Errorf("%w: empty node value", ErrFormat)
+	}
+
+	flags, err := d.ReadByte()
+	if err != nil {
+		return nil, err
+	}
+
+	n := &node{
+		value:     []rune(value),
+		endOfWord: flags&flagEndOfWord != 0,
+	}
+
+	if n.endOfWord {
+		*words++
+	}
+
+	if flags&flagScore != 0 {
+		if err := d.read(d.buf[:8]); err != nil {
+			return nil, err
+		}
+		n.edit().score = math.Float64frombits(binary.LittleEndian.Uint64(d.buf[:8]))
+	}
+
+	if flags&flagForms != 0 {
+		forms, err := d.forms()
+		if err != nil {
+			return nil, err
+		}
+		n.edit().forms = forms
+	}
+
+	if n.children, err = d.nodes(words); err != nil {
+		return nil, err
+	}
+
+	// every node must end a word or branch, otherwise it would have been merged
+	if !n.endOfWord && len(n.children) < 2 {
+		return nil, fmt.Errorf("%w: node does not end a word or branch", ErrFormat)
+	}
+
+	return n, nil
+}
+
+func (d *decoder) forms() ([]displayForm, error) {
+
+	length, err := d.uvarint()
+	if err != nil {
+		return nil, err
+	}
+
+	forms := make([]displayForm, 0)
+	for i := 0; i < length; i++ {
+
+		size, err := d.uvarint()
+		if err != nil {
+			return nil, err
+		}
+
+		text, err := d.text(size)
+		if err != nil {
+			return nil, err
+		}
+
+		count, err := d.uvarint()
+		if err != nil {
+			return nil, err
+		}
+
+		forms = append(forms, displayForm{text: text, count: count})
+	}
+
+	return forms, nil
+}
+
+// text reads length bytes of UTF-8 text as they arrive, rather than trusting
+// the length enough to allocate it up front
+func (d *decoder) text(length int) (string, error) {
+
+	var text bytes.Buffer
+	for i := 0; i < length; i++ {
+		b, err := d.ReadByte()
+		if err != nil {
+			return "", err
+		}
+		text.WriteByte(b)
+	}
+
+	if !utf8.Valid(text.Bytes()) {
+		return "", fmt.Errorf("%w: invalid UTF-8", ErrFormat)
+	}
+
+	return text.String(), nil
+}
+
+// unexpected reports running out of data part way through as a format error
+func unexpected(err error) error {
+	if err == io.EOF {
+		return fmt.Errorf("%w: %v", ErrFormat, io.ErrUnexpectedEOF)
+	}
+
+	return err
+}
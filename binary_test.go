@@ -0,0 +1,197 @@
+package trie
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"hash/crc32"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestWriteToAndReadFrom(t *testing.T) {
+
+	trie := NewTrie()
+	for _, w := range wordsLike {
+		trie.Insert(w)
+	}
+	trie.InsertWeighted("abacus", 2.5)
+	trie.InsertWeighted("zürich", -1)
+
+	var buf bytes.Buffer
+	written, err := trie.WriteTo(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if written != int64(buf.Len()) {
+		t.Errorf("%v bytes should have been written; reported %v", buf.Len(), written)
+	}
+
+	loaded := NewTrie()
+	loaded.Insert("replaced")
+
+	read, err := loaded.ReadFrom(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if read != written {
+		t.Errorf("%v bytes should have been read; reported %v", written, read)
+	}
+
+	if loaded.Count() != trie.Count() {
+		t.Errorf("Trie should contain %v words but found %v", trie.Count(), loaded.Count())
+	}
+
+	if loaded.Contains("replaced") {
+		t.Error("trie should not contain replaced")
+	}
+
+	verifyOrderedMatches(t, loaded.Like("a", -1), trie.Like("a", -1)...)
+	verifyOrderedMatches(t, loaded.LikeTopK("a", 1), "abacus")
+	verifyOrderedMatches(t, loaded.LikeTopK("z", -1), "zürich")
+
+	loaded.Insert("abdominals")
+	loaded.Remove("abdomen")
+	verifyOrderedMatches(t, loaded.Like("abdom", -1), "abdominal", "abdominals", "abdominocentesis")
+}
+
+func TestWriteToEmptyTrie(t *testing.T) {
+
+	var buf bytes.Buffer
+	if _, err := NewTrie().WriteTo(&buf); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded := NewTrie()
+	if _, err := loaded.ReadFrom(&buf); err != nil {
+		t.Fatal(err)
+	}
+
+	if loaded.Count() != 0 {
+		t.Error("trie should have zero words")
+	}
+}
+
+func TestReadFromInvalidData(t *testing.T) {
+
+	trie := NewTrie()
+	trie.Insert("foo")
+	trie.Insert("foobar")
+
+	var buf bytes.Buffer
+	trie.WriteTo(&buf)
+	valid := buf.Bytes()
+
+	corrupt := func(i int, b byte) []byte {
+		data := append([]byte{}, valid...)
+		data[i] = b
+		return data
+	}
+
+	tests := []struct {
+		name string
+		data []byte
+		err  error
+	}{
+		{"empty", []byte{}, ErrFormat},
+		{"header", corrupt(0, 'X'), ErrFormat},
+		{"version", corrupt(4, 99), ErrVersion},
+		{"truncated", valid[:len(valid)-3], ErrFormat},
+		{"checksum", corrupt(len(valid)-1, valid[len(valid)-1]+1), ErrChecksum},
+		{"normalizer", corrupt(5, valid[5]+1), ErrNormalizer},
+		{"count", corrupt(9, 3), ErrFormat},
+	}
+
+	for _, test := range tests {
+
+		loaded := NewTrie()
+		loaded.Insert("untouched")
+
+		if _, err := loaded.ReadFrom(bytes.NewReader(test.data)); !errors.Is(err, test.err) {
+			t.Errorf("[%v] error should be %v; found %v", test.name, test.err, err)
+		}
+
+		if !loaded.Contains("untouched") || loaded.Count() != 1 {
+			t.Errorf("[%v] trie should be unchanged after an error", test.name)
+		}
+	}
+}
+
+func TestReadFromVersionOne(t *testing.T) {
+
+	trie := NewTrie()
+	trie.Insert("foo")
+	trie.InsertWeighted("foobar", 2)
+
+	var buf bytes.Buffer
+	trie.WriteTo(&buf)
+
+	// without display forms the only differences from version one are the version and the fingerprint
+	data := append(buf.Bytes()[:len(magic)+1:len(magic)+1], buf.Bytes()[len(magic)+5:]...)
+	data[len(magic)] = 1
+	binary.BigEndian.PutUint32(data[len(data)-4:], crc32.ChecksumIEEE(data[:len(data)-4]))
+
+	loaded := NewTrie()
+	if _, err := loaded.ReadFrom(bytes.NewReader(data)); err != nil {
+		t.Fatal(err)
+	}
+
+	verifyOrderedMatches(t, loaded.LikeTopK("f", -1), "foobar", "foo")
+}
+
+func TestReadFromLeavesTheRestOfTheReader(t *testing.T) {
+
+	trie := NewTrie()
+	trie.Insert("foo")
+
+	var buf bytes.Buffer
+	written, _ := trie.WriteTo(&buf)
+
+	// a MultiReader is not an io.ByteReader, so the Trie cannot be read through a buffer
+	r := io.MultiReader(&buf, strings.NewReader("TRAILER"))
+
+	loaded := NewTrie()
+	read, err := loaded.ReadFrom(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if read != written {
+		t.Errorf("%v bytes should have been read; reported %v", written, read)
+	}
+
+	rest, _ := io.ReadAll(r)
+	if string(rest) != "TRAILER" {
+		t.Errorf("the data after the Trie should be left unread; found %q", rest)
+	}
+
+	if !loaded.Contains("foo") {
+		t.Error("trie should contain foo")
+	}
+}
+
+func TestReadFromDifferentNormalizer(t *testing.T) {
+
+	trie := NewTrie(WithNormalizer(Identity))
+	trie.Insert("Foo")
+
+	var buf bytes.Buffer
+	trie.WriteTo(&buf)
+
+	loaded := NewTrie()
+	if _, err := loaded.ReadFrom(bytes.NewReader(buf.Bytes())); !errors.Is(err, ErrNormalizer) {
+		t.Errorf("error should be %v; found %v", ErrNormalizer, err)
+	}
+
+	loaded = NewTrie(WithNormalizer(Identity))
+	if _, err := loaded.ReadFrom(bytes.NewReader(buf.Bytes())); err != nil {
+		t.Fatal(err)
+	}
+
+	if !loaded.Contains("Foo") {
+		t.Error("trie should contain Foo")
+	}
+}
@@ -0,0 +1,245 @@
+package trie
+
+import (
+	"encoding/binary"
+	"iter"
+	"sort"
+)
+
+// DAWG is a directed acyclic word graph, a frozen copy of the words in a Trie
+// where every word ending the same way shares the states for that ending, so
+// "nation" and "ration" share "ation". It finds the same words as the Trie it
+// was frozen from, in far less memory when many words share their endings.
+//
+// A DAWG cannot be changed, and is safe for concurrent use. Scores belong to a
+// single word and are not kept. Display forms are kept apart from the graph for
+// the words that have them, so Like returns the spellings the Trie would, while
+// the iterators return the normalized words as the Trie's do.
+type DAWG struct {
+	settings settings
+	root     *state
+	count    int
+	forms    frozenForms
+}
+
+// state is a point in a DAWG, reached by the runes of one or more prefixes
+type state struct {
+	final bool
+	edges []edge
+}
+
+// edge leads from a state to the state reached by the next rune
+type edge struct {
+	r  rune
+	to *state
+}
+
+// Freeze returns the words in the Trie as a minimized DAWG
+func (t *Trie) Freeze() *DAWG {
+	return t.Snapshot().Freeze()
+}
+
+// Freeze returns the words in the Snapshot as a minimized DAWG
+func (s *Snapshot) Freeze() *DAWG {
+
+	b := newDAWGBuilder()
+	forms := make(frozenForms)
+	walkAll(s.children, func(word []rune, n *node) bool {
+		b.add(word)
+		forms.add(s.settings, string(word), n)
+		return true
+	})
+
+	return &DAWG{settings: *s.settings, root: b.finish(), count: s.count, forms: forms}
+}
+
+// Count returns the number of unique words in the DAWG
+func (d *DAWG) Count() int {
+	return d.count
+}
+
+// Contains reports whether the word is in the DAWG
+func (d *DAWG) Contains(word string) bool {
+
+	if len(word) == 0 {
+		return false
+	}
+
+	s := d.root.follow(d.settings.split(word))
+
+	return s != nil && s.final
+}
+
+// Like will find the words in the DAWG that start with the prefix in alphabetical order, up to the supplied count
+func (d *DAWG) Like(prefix string, count int) []string {
+
+	words := make([]string, 0)
+	if len(prefix) == 0 {
+		return words
+	}
+
+	p := d.settings.split(prefix)
+	d.root.follow(p).walk(p, func(word []rune) bool {
+		if count >= 0 && len(words) >= count {
+			return false
+		}
+		words = d.forms.display(words, string(word), count)
+		return true
+	})
+
+	return words
+}
+
+// All returns an iterator over every word in the DAWG in alphabetical order
+func (d *DAWG) All() iter.Seq[string] {
+	return d.WithPrefix("")
+}
+
+// WithPrefix returns an iterator over the words in the DAWG that start with the prefix in alphabetical order
+func (d *DAWG) WithPrefix(prefix string) iter.Seq[string] {
+
+	return func(yield func(string) bool) {
+
+		p := d.settings.split(prefix)
+		d.root.follow(p).walk(p, func(word []rune) bool {
+			return yield(string(word))
+		})
+	}
+}
+
+// follow returns the state reached from s by the runes of the word, or nil if the word leaves the graph
+func (s *state) follow(word []rune) *state {
+
+	for _, r := range word {
+
+		i := sort.Search(len(s.edges), func(i int) bool { return s.edges[i].r >= r })
+		if i == len(s.edges) || s.edges[i].r != r {
+			return nil
+		}
+
+		s = s.edges[i].to
+	}
+
+	return s
+}
+
+// walk visits every word from s onwards in rune order, where word holds the
+// runes that reached s. The walk stops as soon as fn returns false.
+func (s *state) walk(word []rune, fn func([]rune) bool) bool {
+
+	if s == nil {
+		return true
+	}
+
+	if s.final && !fn(word) {
+		return false
+	}
+
+	for _, e := range s.edges {
+		if !e.to.walk(append(word, e.r), fn) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// dawgBuilder makes a minimized DAWG from words in sorted order. Once a word
+// is added, the states of the last word past the runes the two share can no
+// longer change, so each is swapped for an equal state already in the graph,
+// or kept as the first of its kind.
+type dawgBuilder struct {
+	root *state
+	last []rune
+
+	// unchecked holds the states along the last word that have not been minimized,
+	// along with the state and rune leading to each of them
+	unchecked []uncheckedState
+
+	// register holds every minimized state by what it leads to, and ids numbers them
+	register map[string]*state
+	ids      map[*state]uint64
+}
+
+type uncheckedState struct {
+	parent *state
+	r      rune
+	child  *state
+}
+
+func newDAWGBuilder() *dawgBuilder {
+	return &dawgBuilder{
+		root:     &state{},
+		register: make(map[string]*state),
+		ids:      make(map[*state]uint64),
+	}
+}
+
+// add adds a word that comes after every word added before it
+func (b *dawgBuilder) add(word []rune) {
+
+	common := commonPrefix(b.last, word)
+	b.minimize(common)
+
+	s := b.root
+	if len(b.unchecked) > 0 {
+		s = b.unchecked[len(b.unchecked)-1].child
+	}
+
+	for _, r := range word[common:] {
+		next := &state{}
+		s.edges = append(s.edges, edge{r: r, to: next})
+		b.unchecked = append(b.unchecked, uncheckedState{parent: s, r: r, child: next})
+		s = next
+	}
+
+	s.final = true
+	b.last = append(b.last[:0], word...)
+}
+
+// finish minimizes the states of the last word and returns the root
+func (b *dawgBuilder) finish() *state {
+	b.minimize(0)
+	return b.root
+}
+
+// minimize swaps the unchecked states deeper than the depth for their equals
+func (b *dawgBuilder) minimize(depth int) {
+
+	for len(b.unchecked) > depth {
+
+		u := b.unchecked[len(b.unchecked)-1]
+		b.unchecked = b.unchecked[:len(b.unchecked)-1]
+
+		key := b.key(u.child)
+		if existing, found := b.register[key]; found {
+			u.parent.edges[len(u.parent.edges)-1].to = existing
+			continue
+		}
+
+		// the state will not gain any more edges, so it need not keep room for them
+		u.child.edges = u.child.edges[:len(u.child.edges):len(u.child.edges)]
+
+		b.register[key] = u.child
+		b.ids[u.child] = uint64(len(b.ids))
+	}
+}
+
+// key describes everything a state leads to, so that equal states have equal
+// keys. Every state it leads to has already been minimized.
+func (b *dawgBuilder) key(s *state) string {
+
+	key := make([]byte, 0, 1+len(s.edges)*2*binary.MaxVarintLen32)
+	if s.final {
+		key = append(key, 1)
+	} else {
+		key = append(key, 0)
+	}
+
+	for _, e := range s.edges {
+		key = binary.AppendUvarint(key, uint64(e.r))
+		key = binary.AppendUvarint(key, b.ids[e.to])
+	}
+
+	return string(key)
+}
@@ -0,0 +1,125 @@
+package trie
+
+import "testing"
+
+func TestFreezeFindsTheSameWords(t *testing.T) {
+
+	trie := NewTrie()
+	for _, w := range append(append([]string{}, wordsLike...), wordsAlphabet...) {
+		trie.Insert(w)
+	}
+
+	dawg := trie.Freeze()
+
+	if dawg.Count() != trie.Count() {
+		t.Errorf("dawg should hold %v words; found %v", trie.Count(), dawg.Count())
+	}
+
+	for _, prefix := range []string{"a", "ab", "abd", "Aa", "abdomen", "z", "x", "", "abdomenx"} {
+		verifyOrderedMatches(t, dawg.Like(prefix, -1), trie.Like(prefix, -1)...)
+		verifyOrderedMatches(t, dawg.Like(prefix, 3), trie.Like(prefix, 3)...)
+	}
+
+	for _, w := range []string{"abdomen", "ABDOMEN", "abdom", "zulu", "zul", ""} {
+		if dawg.Contains(w) != trie.Contains(w) {
+			t.Errorf("dawg and trie should agree on whether %v is stored", w)
+		}
+	}
+
+	words := make([]string, 0)
+	for w := range dawg.All() {
+		words = append(words, w)
+	}
+
+	expected := make([]string, 0)
+	for w := range trie.All() {
+		expected = append(expected, w)
+	}
+
+	verifyOrderedMatches(t, words, expected...)
+
+	words = words[:0]
+	for w := range dawg.WithPrefix("abd") {
+		words = append(words, w)
+	}
+
+	verifyOrderedMatches(t, words, trie.Like("abd", -1)...)
+}
+
+func TestFreezeSharesSuffixes(t *testing.T) {
+
+	trie := NewTrie()
+	for _, w := range []string{"nation", "ration", "station", "nations", "rations", "stations"} {
+		trie.Insert(w)
+	}
+
+	dawg := trie.Freeze()
+
+	// the root, the state after "s", and a single chain for "ation" with an optional "s"
+	// that "n", "r" and "st" all lead to
+	if states := countStates(dawg.root, make(map[*state]bool)); states != 9 {
+		t.Errorf("dawg should have 9 states; found %v", states)
+	}
+
+	verifyOrderedMatches(t, dawg.Like("st", -1), "station", "stations")
+
+	if dawg.Contains("sation") || !dawg.Contains("rations") {
+		t.Error("sharing suffixes should not add or lose words")
+	}
+}
+
+func TestFreezeDoesNotSeeLaterChanges(t *testing.T) {
+
+	trie := NewTrie(WithNormalizer(Identity))
+	trie.Insert("Alpha")
+
+	dawg := trie.Freeze()
+	trie.Insert("Alphabet")
+	trie.Remove("Alpha")
+
+	verifyOrderedMatches(t, dawg.Like("Al", -1), "Alpha")
+	verifyMatches(t, dawg.Like("al", -1))
+
+	if NewTrie().Freeze().Count() != 0 {
+		t.Error("an empty trie should freeze into an empty dawg")
+	}
+}
+
+// countStates counts the distinct states reachable from s
+func countStates(s *state, seen map[*state]bool) int {
+
+	if seen[s] {
+		return 0
+	}
+	seen[s] = true
+
+	count := 1
+	for _, e := range s.edges {
+		count += countStates(e.to, seen)
+	}
+
+	return count
+}
+
+func TestFreezeKeepsDisplayForms(t *testing.T) {
+
+	trie := NewTrie(WithAccentFolding())
+	trie.Insert("Zürich")
+	trie.Insert("zurich")
+	trie.Insert("Zug")
+
+	dawg := trie.Freeze()
+
+	verifyOrderedMatches(t, dawg.Like("zur", 5), trie.Like("zur", 5)...)
+	verifyOrderedMatches(t, dawg.Like("Z", -1), "Zug", "Zürich")
+
+	trie = NewTrie(WithDisplayForms(AllForms))
+	trie.Insert("Apple")
+	trie.Insert("APPLE")
+	trie.Insert("apricot")
+
+	dawg = trie.Freeze()
+
+	verifyOrderedMatches(t, dawg.Like("a", -1), "Apple", "APPLE", "apricot")
+	verifyOrderedMatches(t, dawg.Like("a", 1), "Apple")
+}
@@ -0,0 +1,135 @@
+package trie
+
+// DisplayPolicy decides which of the spellings a word was inserted with are
+// returned when the word is found
+type DisplayPolicy int
+
+const (
+	// FirstForm returns the spelling the word was first inserted with
+	FirstForm DisplayPolicy = iota + 1
+
+	// MostFrequentForm returns the spelling the word was inserted with most often, preferring the earliest
+	// spelling when there is a tie
+	MostFrequentForm
+
+	// AllForms returns every spelling the word was inserted with, in the order they were first inserted
+	AllForms
+)
+
+// displayForm is a spelling a word was inserted with, and how many times it was inserted that way
+type displayForm struct {
+	text  string
+	count int
+}
+
+// WithDisplayForms keeps the spellings words were inserted with, so that searches return "Aachen" rather than
+// the normalized "aachen" while still matching in the normalized form. The policy decides which spellings are
+// returned when a word was inserted in more than one way. Iterators and the text and JSON marshalers return the
+// normalized words.
+func WithDisplayForms(policy DisplayPolicy) Option {
+	return func(t *Trie) { t.display = policy }
+}
+
+// addForm records another insertion of the word spelled as text. The forms may
+// be shared with an earlier copy of n, so they are copied rather than changed.
+func (n *node) addForm(text string) {
+
+	p := n.edit()
+
+	forms := make([]displayForm, len(p.forms), len(p.forms)+1)
+	copy(forms, p.forms)
+	p.forms = forms
+
+	for i := range p.forms {
+		if p.forms[i].text == text {
+			p.forms[i].count++
+			return
+		}
+	}
+
+	p.forms = append(p.forms, displayForm{text: text, count: 1})
+}
+
+// forms returns the spellings of the word ending on n that the policy picks
+func (c *settings) forms(word string, n *node) []string {
+
+	if c.display == 0 || n == nil || len(n.forms()) == 0 {
+		return []string{word}
+	}
+
+	forms := n.forms()
+
+	switch c.display {
+	case MostFrequentForm:
+		best := forms[0]
+		for _, f := range forms[1:] {
+			if f.count > best.count {
+				best = f
+			}
+		}
+		return []string{best.text}
+	case AllForms:
+		texts := make([]string, len(forms))
+		for i, f := range forms {
+			texts[i] = f.text
+		}
+		return texts
+	default:
+		return []string{forms[0].text}
+	}
+}
+
+// displayWords replaces each of the stored words with the spellings the policy
+// picks, up to the supplied count
+func (s *Snapshot) displayWords(words []string, count int) []string {
+
+	if s.display == 0 {
+		return words
+	}
+
+	display := make([]string, 0, len(words))
+	for _, w := range words {
+
+		_, n := contains(s.children, []rune(w))
+
+		for _, f := range s.forms(w, n) {
+			if count >= 0 && len(display) >= count {
+				return display
+			}
+			display = append(display, f)
+		}
+	}
+
+	return display
+}
+
+// frozenForms holds the spellings the display policy picks for the words of a
+// frozen copy of a Trie, so that the copy returns words as the Trie does. Only
+// the words that are not returned as they are stored are kept.
+type frozenForms map[string][]string
+
+// add records the spellings of the word ending on n, if they are not just the word
+func (f frozenForms) add(c *settings, word string, n *node) {
+
+	if forms := c.forms(word, n); len(forms) != 1 || forms[0] != word {
+		f[word] = forms
+	}
+}
+
+// display appends the spellings of the stored word to words, up to the supplied count
+func (f frozenForms) display(words []string, word string, count int) []string {
+
+	forms, found := f[word]
+	if !found {
+		forms = []string{word}
+	}
+
+	for _, w := range forms {
+		if count >= 0 && len(words) >= count {
+			break
+		}
+		words = append(words, w)
+	}
+
+	return words
+}
@@ -0,0 +1,127 @@
+package trie
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestDisplayFormsDisabled(t *testing.T) {
+
+	trie := NewTrie()
+	trie.Insert("Aachen")
+
+	verifyOrderedMatches(t, trie.Like("aa", -1), "aachen")
+}
+
+func TestFirstForm(t *testing.T) {
+
+	trie := NewTrie(WithDisplayForms(FirstForm))
+	trie.Insert("Aachen")
+	trie.Insert("iPhone")
+	trie.Insert("IPHONE")
+	trie.Insert("IPHONE")
+
+	verifyOrderedMatches(t, trie.Like("AA", -1), "Aachen")
+	verifyOrderedMatches(t, trie.Like("i", -1), "iPhone")
+
+	if !trie.Contains("aachen") {
+		t.Error("trie should still match case insensitively")
+	}
+}
+
+func TestMostFrequentForm(t *testing.T) {
+
+	trie := NewTrie(WithDisplayForms(MostFrequentForm))
+	trie.Insert("iphone")
+	trie.Insert("iPhone")
+	trie.Insert("IPHONE")
+	trie.Insert("iPhone")
+
+	verifyOrderedMatches(t, trie.Like("i", -1), "iPhone")
+
+	trie.Insert("Tie")
+	trie.Insert("TIE")
+
+	verifyOrderedMatches(t, trie.Like("t", -1), "Tie")
+}
+
+func TestAllForms(t *testing.T) {
+
+	trie := NewTrie(WithDisplayForms(AllForms))
+	trie.Insert("Polish")
+	trie.Insert("polish")
+	trie.Insert("Polish")
+	trie.Insert("pole")
+
+	verifyOrderedMatches(t, trie.Like("pol", -1), "pole", "Polish", "polish")
+	verifyOrderedMatches(t, trie.Like("pol", 2), "pole", "Polish")
+}
+
+func TestRemoveForgetsDisplayForms(t *testing.T) {
+
+	trie := NewTrie(WithDisplayForms(AllForms))
+	trie.Insert("Aachen")
+	trie.Remove("aachen")
+	trie.Insert("AACHEN")
+
+	verifyOrderedMatches(t, trie.Like("a", -1), "AACHEN")
+}
+
+func TestDisplayFormsSurviveSplitsAndMerges(t *testing.T) {
+
+	trie := NewTrie(WithDisplayForms(FirstForm))
+	trie.Insert("FooBar")
+	trie.Insert("Foo")
+	trie.Insert("FooBaz")
+	trie.Remove("foobaz")
+	trie.Remove("foo")
+
+	verifyOrderedMatches(t, trie.Like("f", -1), "FooBar")
+}
+
+func TestDisplayFormsInOtherSearches(t *testing.T) {
+
+	trie := NewTrie(WithDisplayForms(FirstForm), WithSuffixIndex(), WithSubstringIndex())
+	trie.InsertWeighted("Zürich", 2)
+	trie.InsertWeighted("Zug", 1)
+	trie.Insert("Aachen")
+
+	verifyOrderedMatches(t, trie.LikeTopK("z", -1), "Zürich", "Zug")
+	verifyOrderedMatches(t, trie.LikeAfter("z", "ZUG", -1), "Zürich")
+	verifyOrderedMatches(t, trie.Match("z*", -1), "Zug", "Zürich")
+	verifyOrderedMatches(t, trie.Range("a", "zug", -1), "Aachen", "Zug")
+	verifyOrderedMatches(t, trie.EndsWith("ICH", -1), "Zürich")
+	verifyOrderedMatches(t, trie.Substring("che", -1), "Aachen")
+	verifyOrderedMatches(t, trie.Prefixes("zugspitze"), "Zug")
+
+	words, _ := trie.MatchRegexp(`^z`, -1)
+	verifyOrderedMatches(t, words, "Zug", "Zürich")
+
+	if word, _ := trie.LongestPrefix("aachener"); word != "Aachen" {
+		t.Errorf("longest prefix should be Aachen; found %v", word)
+	}
+
+	verifyFuzzyMatches(t, trie.LikeFuzzy("zog", 1, -1), FuzzyMatch{"Zug", 1})
+}
+
+func TestDisplayFormsAreWrittenAndRead(t *testing.T) {
+
+	trie := NewTrie(WithDisplayForms(MostFrequentForm))
+	trie.Insert("iPhone")
+	trie.Insert("IPHONE")
+	trie.Insert("IPHONE")
+
+	var buf bytes.Buffer
+	if _, err := trie.WriteTo(&buf); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded := NewTrie(WithDisplayForms(MostFrequentForm))
+	if _, err := loaded.ReadFrom(&buf); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded.Insert("iPhone")
+	loaded.Insert("iPhone")
+	verifyOrderedMatches(t, loaded.Like("i", -1), "iPhone")
+}
@@ -3,7 +3,8 @@
 // input would return likely matches.
 //
 // Both reads and write are thread safe; however, one one write may occur at any
-// one time.  Concurrent reads are not constrained.
+// one time.  Reads never wait for writes, as every write builds a new version
+// of the tree that shares the unchanged parts of the old one.
 //
 // Usage would start by creating the Trie
 //
@@ -18,6 +19,78 @@
 //
 //	words := trie.Like("foo", 5)
 //
+// To show more results after a page of words, ask for the words after the last
+// word of the page.
+//
+//	more := trie.LikeAfter("foo", words[len(words)-1], 5)
+//
+// Like returns words in alphabetical order. When some words are more relevant
+// than others, insert them with a score and ask for the highest ranked
+// completions instead.
+//
+//	trie.InsertWeighted("foobar", 42)
+//	words := trie.LikeTopK("foo", 5)
+//
+// Typos in the prefix can be tolerated by allowing a number of edits. Each
+// match reports how many edits away from the prefix it was.
+//
+//	matches := trie.LikeFuzzy("fobo", 2, 5)
+//
+// Iteration
+//
+// Large result sets can be streamed instead of collected into a slice. All and
+// WithPrefix return iterators for range loops, and an Iterator can be moved to
+// any key with Seek and then advanced with Next.
+//
+//	for word := range trie.WithPrefix("foo") {
+//		fmt.Println(word)
+//	}
+//
+//	it := trie.Iterator()
+//	it.Seek("foo")
+//	for it.Next() {
+//		fmt.Println(it.Word())
+//	}
+//
+// Words between two keys can be found with Range, which can also leave out
+// either bound and return the words in reverse.
+//
+//	words := trie.Range("apple", "apricot", 10, ExclusiveTo(), Reverse())
+//
+// Prefix Matching
+//
+// The words an input starts with can be found as well, which is useful for
+// tokenizers and routing tables.
+//
+//	word, found := trie.LongestPrefix("foobarbaz")
+//	words := trie.Prefixes("foobarbaz")
+//
+// Suffixes
+//
+// A Trie created with WithSuffixIndex also keeps track of how each word ends,
+// so words can be found by their suffix.
+//
+//	trie := NewTrie(WithSuffixIndex())
+//	words := trie.EndsWith("bar", 5)
+//
+// Likewise, a Trie created with WithSubstringIndex can find words by any part
+// of them.
+//
+//	trie := NewTrie(WithSubstringIndex())
+//	words := trie.Substring("oba", 5)
+//
+// Patterns
+//
+// Words can also be found with a pattern, where '?' matches any one rune and
+// '*' matches any number of runes.
+//
+//	words := trie.Match("f?o*", 5)
+//
+// Regular expressions are supported as well, and are run against the tree
+// directly rather than against every word.
+//
+//	words, err := trie.MatchRegexp(`^fo+(bar|baz)$`, 5)
+//
 // CRUD Operations
 //
 // The Trie starts empty, so you would need to populate it to get any value back.
@@ -37,12 +110,109 @@
 // Trie stores all strings as lowercase. Asking whether a Trie contains "foobar"
 // or "FOOBAR" would yield the same results.
 //
+// How words are stored can be changed by supplying a Normalizer. Identity keeps
+// words exactly as they are, while CaseFold, NFC and NFKC apply the Unicode
+// rules of the same names. Normalizers can be combined with Chain.
+//
+//	trie := NewTrie(WithNormalizer(Chain(NFKC, CaseFold)))
+//
+// Searches return words in their normalized form, so "Aachen" is found as
+// "aachen". To get back the spelling a word was inserted with, keep display
+// forms as well.
+//
+//	trie := NewTrie(WithDisplayForms(FirstForm))
+//	trie.Insert("Aachen")
+//	words := trie.Like("aa", 5) // ["Aachen"]
+//
+// To match words whatever their case or accents, use accent folding. Words
+// are stored with their accents removed, and returned as they were inserted.
+//
+//	trie := NewTrie(WithAccentFolding())
+//	trie.Insert("Zürich")
+//	words := trie.Like("zur", 5) // ["Zürich"]
+//
+// Entries made of several words can be found by any of their words with a
+// phrase index. Each word of the query must start a word of the entry.
+//
+//	trie := NewTrie(WithPhraseIndex())
+//	trie.Insert("Kansas City")
+//	words := trie.LikePhrase("city ka", 5) // ["kansas city"]
+//
 // You may see how many unique words are stored in the Trie by invoking Count().
 //
 //	count := trie.Count()
 //
+// Stats describes the shape of the tree and estimates how much memory it uses.
+//
+//	stats := trie.Stats()
+//
 // If you need to remove a word from the Trie, invoke Remove().
 //
 //	trie.Remove("foobar")
 //
+// Snapshots
+//
+// Snapshot returns the words in the Trie as they are at that moment. Searching a
+// Snapshot gives the same results however the Trie changes afterwards, and it
+// costs nothing to take one.
+//
+//	snapshot := trie.Snapshot()
+//	trie.Remove("foobar")
+//	found := snapshot.Contains("foobar") // true
+//
+// A Batch collects inserts and removes that Apply makes all at once, so no
+// search ever sees some of them without the rest.
+//
+//	var b Batch
+//	b.Insert("foo")
+//	b.Remove("bar")
+//	results, err := trie.Apply(&b)
+//
+// When many goroutines insert at once, a ShardedTrie spreads the words across
+// several Tries so that most writers do not wait for each other.
+//
+//	sharded := NewShardedTrie(8)
+//	sharded.Insert("foobar")
+//	words := sharded.Like("foo", 5)
+//
+// Word lists that never change can be frozen into a DAWG, which shares the
+// endings words have in common and so uses far less memory than the Trie.
+//
+//	dawg := trie.Freeze()
+//	words := dawg.Like("foo", 5)
+//
+// When looking words up has to be as fast as possible, compile them into a
+// DoubleArray, which finds each byte of a word with a couple of array reads.
+//
+//	da := trie.Compile()
+//	found := da.Contains("foobar")
+//
+// Persistence
+//
+// A Trie can be saved with WriteTo and loaded again with ReadFrom, which is
+// much faster than inserting every word again. The Trie loading the words must
+// use the same Normalizer as the Trie that saved them.
+//
+//	_, err := trie.WriteTo(file)
+//	_, err = trie.ReadFrom(bufio.NewReader(file))
+//
+// Trie also implements the marshaler interfaces from the encoding packages, so
+// it can be stored with encoding/gob, encoding/json or as plain text with one
+// word on each line.
+//
+// A Trie can be built from a sorted list of words much faster than the words
+// can be inserted one at a time.
+//
+//	trie, err := BuildFromSorted(file)
+//
+// Maps
+//
+// When every word needs to carry some data, use a Map instead. A Map stores
+// keys the same way a Trie stores words, and associates a value with each key.
+//
+//	m := NewMap[int]()
+//	m.Put("foobar", 42)
+//	value, found := m.Get("foobar")
+//	entries := m.Like("foo", 5)
+//
 package trie
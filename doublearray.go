@@ -0,0 +1,330 @@
+package trie
+
+// DoubleArray is a frozen copy of the words in a Trie laid out as a double
+// array, where the state reached by each byte of a word is found by adding the
+// byte to the base of the state before it, and confirmed by that state being
+// recorded as its check. Looking up a word reads two arrays once for each byte
+// of it, rather than searching the children of a node for each rune.
+//
+// A DoubleArray cannot be changed, and is safe for concurrent use. Scores are
+// not kept, while display forms are kept apart from the arrays for the words
+// that have them, so words are returned spelled as the Trie would return them.
+type DoubleArray struct {
+	settings settings
+	count    int
+	forms    frozenForms
+
+	// base and check are indexed by state, with the root at zero. A state with
+	// no parent has a check of -1. final marks the states that end a word.
+	base  []int32
+	check []int32
+	final []bool
+
+	// child holds the first byte leading on from each state, and sibling the next
+	// byte leading on from the parent of each state, or -1 when there is none.
+	// They let a walk visit only the states that are used.
+	child   []int16
+	sibling []int16
+}
+
+// Compile returns the words in the Trie as a DoubleArray
+func (t *Trie) Compile() *DoubleArray {
+	return t.Snapshot().Compile()
+}
+
+// Compile returns the words in the Snapshot as a DoubleArray
+func (s *Snapshot) Compile() *DoubleArray {
+
+	words := make([][]byte, 0, s.count)
+	forms := make(frozenForms)
+	walkAll(s.children, func(word []rune, n *node) bool {
+		words = append(words, []byte(string(word)))
+		forms.add(s.settings, string(word), n)
+		return true
+	})
+
+	da := &DoubleArray{settings: *s.settings, count: s.count, forms: forms}
+	b := &doubleArrayBuilder{da: da, head: -1, tail: -1}
+	b.grow(1)
+	b.use(0, 0)
+
+	if len(words) > 0 {
+		b.place(words, 0, 0)
+		da.trim()
+	}
+
+	return da
+}
+
+// Count returns the number of unique words in the DoubleArray
+func (da *DoubleArray) Count() int {
+	return da.count
+}
+
+// Contains reports whether the word is in the DoubleArray
+func (da *DoubleArray) Contains(word string) bool {
+
+	if len(word) == 0 {
+		return false
+	}
+
+	s, ok := da.follow(da.settings.normalize(word))
+
+	return ok && da.final[s]
+}
+
+// Like will find the words in the DoubleArray that start with the prefix in alphabetical order, up to the
+// supplied count
+func (da *DoubleArray) Like(prefix string, count int) []string {
+
+	words := make([]string, 0)
+	if len(prefix) == 0 {
+		return words
+	}
+
+	key := da.settings.normalize(prefix)
+
+	s, ok := da.follow(key)
+	if !ok {
+		return words
+	}
+
+	da.walk(s, []byte(key), func(word []byte) bool {
+		if count >= 0 && len(words) >= count {
+			return false
+		}
+		words = da.forms.display(words, string(word), count)
+		return true
+	})
+
+	return words
+}
+
+// Prefixes returns every word in the DoubleArray that the input starts with, from shortest to longest
+func (da *DoubleArray) Prefixes(input string) []string {
+
+	words := make([]string, 0)
+	if len(input) == 0 {
+		return words
+	}
+
+	key := da.settings.normalize(input)
+
+	var s int32
+	for i := 0; i < len(key); i++ {
+
+		var ok bool
+		if s, ok = da.next(s, key[i]); !ok {
+			break
+		}
+
+		if da.final[s] {
+			words = da.forms.display(words, key[:i+1], -1)
+		}
+	}
+
+	return words
+}
+
+// next returns the state reached from s by the byte, and whether there is one
+func (da *DoubleArray) next(s int32, c byte) (int32, bool) {
+
+	t := da.base[s] + code(c)
+	if t >= int32(len(da.check)) || da.check[t] != s {
+		return 0, false
+	}
+
+	return t, true
+}
+
+// follow returns the state reached from the root by the bytes of the key, and whether there is one
+func (da *DoubleArray) follow(key string) (int32, bool) {
+
+	var s int32
+	for i := 0; i < len(key); i++ {
+
+		var ok bool
+		if s, ok = da.next(s, key[i]); !ok {
+			return 0, false
+		}
+	}
+
+	return s, true
+}
+
+// walk visits every word from s onwards in byte order, which is also rune
+// order, where word holds the bytes that reached s. The walk stops as soon as
+// fn returns false.
+func (da *DoubleArray) walk(s int32, word []byte, fn func([]byte) bool) bool {
+
+	if da.final[s] && !fn(word) {
+		return false
+	}
+
+	for c := da.child[s]; c >= 0; {
+
+		t := da.base[s] + code(byte(c))
+		if !da.walk(t, append(word, byte(c)), fn) {
+			return false
+		}
+
+		c = da.sibling[t]
+	}
+
+	return true
+}
+
+// grow extends the arrays to hold at least n states
+func (da *DoubleArray) grow(n int) {
+
+	for len(da.check) < n {
+		da.base = append(da.base, 0)
+		da.check = append(da.check, -1)
+		da.final = append(da.final, false)
+		da.child = append(da.child, -1)
+		da.sibling = append(da.sibling, -1)
+	}
+}
+
+// trim drops the unused states from the end of the arrays
+func (da *DoubleArray) trim() {
+
+	n := len(da.check)
+	for n > 0 && da.check[n-1] < 0 {
+		n--
+	}
+
+	da.base = da.base[:n:n]
+	da.check = da.check[:n:n]
+	da.final = da.final[:n:n]
+	da.child = da.child[:n:n]
+	da.sibling = da.sibling[:n:n]
+}
+
+// code is what a byte adds to the base of a state, which is never zero so that
+// no state can lead back to the root
+func code(c byte) int32 {
+	return int32(c) + 1
+}
+
+// doubleArrayBuilder places the states of sorted words into a DoubleArray
+type doubleArrayBuilder struct {
+	da *DoubleArray
+
+	// next and prev link the unused states in order, from head to tail, so that
+	// finding a base only looks at states that could take the first byte
+	next []int32
+	prev []int32
+	head int32
+	tail int32
+}
+
+// place records the state s, which the words all reach after their first depth
+// bytes, and then places the states after it
+func (b *doubleArrayBuilder) place(words [][]byte, depth int, s int32) {
+
+	if len(words[0]) == depth {
+		b.da.final[s] = true
+		words = words[1:]
+	}
+
+	if len(words) == 0 {
+		return
+	}
+
+	// the words are sorted, so the words going through each next byte are together
+	bytes := make([]byte, 0)
+	starts := make([]int, 0)
+	for i, w := range words {
+		if i == 0 || w[depth] != words[i-1][depth] {
+			bytes = append(bytes, w[depth])
+			starts = append(starts, i)
+		}
+	}
+	starts = append(starts, len(words))
+
+	base := b.findBase(bytes)
+	b.da.base[s] = base
+	b.da.child[s] = int16(bytes[0])
+	for i, c := range bytes {
+		b.use(base+code(c), s)
+		if i+1 < len(bytes) {
+			b.da.sibling[base+code(c)] = int16(bytes[i+1])
+		}
+	}
+
+	for i, c := range bytes {
+		b.place(words[starts[i]:starts[i+1]], depth+1, base+code(c))
+	}
+}
+
+// findBase returns the first base where the state for every byte is unused
+func (b *doubleArrayBuilder) findBase(bytes []byte) int32 {
+
+	first, last := code(bytes[0]), code(bytes[len(bytes)-1])
+
+	for t := b.head; ; t = b.next[t] {
+
+		if t < 0 {
+			// every unused state has been tried, so try the next one past the end
+			t = int32(len(b.da.check))
+			b.grow(int(t) + 1)
+		}
+
+		base := t - first
+		if base < 0 {
+			continue
+		}
+
+		b.grow(int(base + last + 1))
+
+		fits := true
+		for _, c := range bytes[1:] {
+			if b.da.check[base+code(c)] >= 0 {
+				fits = false
+				break
+			}
+		}
+
+		if fits {
+			return base
+		}
+	}
+}
+
+// grow extends the arrays to hold at least n states, linking the new states onto the end of the unused ones
+func (b *doubleArrayBuilder) grow(n int) {
+
+	for t := int32(len(b.da.check)); int(t) < n; t++ {
+
+		b.next = append(b.next, -1)
+		b.prev = append(b.prev, b.tail)
+
+		if b.tail < 0 {
+			b.head = t
+		} else {
+			b.next[b.tail] = t
+		}
+		b.tail = t
+	}
+
+	b.da.grow(n)
+}
+
+// use records s as the check of the state t, and unlinks t from the unused states
+func (b *doubleArrayBuilder) use(t int32, s int32) {
+
+	b.da.check[t] = s
+
+	if b.prev[t] < 0 {
+		b.head = b.next[t]
+	} else {
+		b.next[b.prev[t]] = b.next[t]
+	}
+
+	if b.next[t] < 0 {
+		b.tail = b.prev[t]
+	} else {
+		b.prev[b.next[t]] = b.prev[t]
+	}
+}
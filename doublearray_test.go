@@ -0,0 +1,206 @@
+package trie
+
+import (
+	"math/rand"
+	"sync"
+	"testing"
+)
+
+func TestCompileFindsTheSameWords(t *testing.T) {
+
+	trie := NewTrie()
+	for _, w := range append(append([]string{}, wordsLike...), wordsAlphabet...) {
+		trie.Insert(w)
+	}
+
+	da := trie.Compile()
+
+	if da.Count() != trie.Count() {
+		t.Errorf("double array should hold %v words; found %v", trie.Count(), da.Count())
+	}
+
+	for _, prefix := range []string{"a", "ab", "abd", "Aa", "abdomen", "z", "x", "", "abdomenx"} {
+		verifyOrderedMatches(t, da.Like(prefix, -1), trie.Like(prefix, -1)...)
+		verifyOrderedMatches(t, da.Like(prefix, 3), trie.Like(prefix, 3)...)
+	}
+
+	for _, w := range append([]string{"ABDOMEN", "abdom", "zul", "", "abdomenx"}, wordsLike...) {
+		if da.Contains(w) != trie.Contains(w) {
+			t.Errorf("double array and trie should agree on whether %v is stored", w)
+		}
+	}
+
+	for _, input := range []string{"abdominocentesis", "aaronites", "zulus", "b", ""} {
+		verifyOrderedMatches(t, da.Prefixes(input), trie.Prefixes(input)...)
+	}
+}
+
+func TestCompileMultiByteRunes(t *testing.T) {
+
+	trie := NewTrie()
+	trie.Insert("café")
+	trie.Insert("cafè")
+	trie.Insert("caf")
+	trie.Insert("日本")
+	trie.Insert("日本語")
+
+	da := trie.Compile()
+
+	verifyOrderedMatches(t, da.Like("caf", -1), trie.Like("caf", -1)...)
+	verifyOrderedMatches(t, da.Like("日", -1), "日本", "日本語")
+	verifyOrderedMatches(t, da.Prefixes("日本語です"), "日本", "日本語")
+
+	if !da.Contains("CAFÉ") || da.Contains("cafe") {
+		t.Error("double array should match words as the trie normalizes them")
+	}
+}
+
+func TestCompileEmptyTrie(t *testing.T) {
+
+	da := NewTrie().Compile()
+
+	if da.Count() != 0 || da.Contains("a") {
+		t.Error("an empty trie should compile into an empty double array")
+	}
+
+	verifyMatches(t, da.Like("a", -1))
+	verifyMatches(t, da.Prefixes("a"))
+}
+
+// benchmarkWords is a dictionary the size of a real one, made of random
+// syllables so that words share their beginnings and endings as real words do
+var benchmarkWords = sync.OnceValue(func() []string {
+
+	syllables := []string{"a", "ab", "ac", "al", "an", "ar", "ba", "be", "bo", "ca", "ce", "co", "da", "de", "di",
+		"el", "en", "er", "es", "fa", "fi", "ga", "ge", "ha", "he", "in", "is", "ka", "la", "le", "li", "lo", "ma",
+		"me", "mi", "mo", "na", "ne", "no", "on", "or", "pa", "pe", "po", "ra", "re", "ri", "ro", "sa", "se", "si",
+		"so", "ta", "te", "ti", "to", "tra", "un", "ur", "va", "ve", "vi", "za", "zu"}
+	endings := []string{"", "", "s", "ed", "er", "ing", "ion", "ly", "ness"}
+
+	r := rand.New(rand.NewSource(1))
+	words := make([]string, 200000)
+	for i := range words {
+		var w string
+		for j := 1 + r.Intn(4); j > 0; j-- {
+			w += syllables[r.Intn(len(syllables))]
+		}
+		words[i] = w + endings[r.Intn(len(endings))]
+	}
+
+	return words
+})
+
+func TestCompileLargeDictionary(t *testing.T) {
+
+	trie := NewTrie()
+	for _, w := range benchmarkWords() {
+		trie.Insert(w)
+	}
+
+	da := trie.Compile()
+
+	if da.Count() != trie.Count() {
+		t.Fatalf("double array should hold %v words; found %v", trie.Count(), da.Count())
+	}
+
+	for _, w := range benchmarkWords()[:1000] {
+		if !da.Contains(w) {
+			t.Errorf("double array should contain %v", w)
+		}
+	}
+
+	verifyOrderedMatches(t, da.Like("tra", 20), trie.Like("tra", 20)...)
+}
+
+func BenchmarkCompile(b *testing.B) {
+
+	trie := NewTrie()
+	for _, w := range benchmarkWords() {
+		trie.Insert(w)
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		trie.Compile()
+	}
+}
+
+func TestCompileKeepsDisplayForms(t *testing.T) {
+
+	trie := NewTrie(WithAccentFolding())
+	trie.Insert("Zürich")
+	trie.Insert("Zug")
+
+	da := trie.Compile()
+
+	verifyOrderedMatches(t, da.Like("zur", 5), trie.Like("zur", 5)...)
+	verifyOrderedMatches(t, da.Prefixes("zurichsee"), trie.Prefixes("zurichsee")...)
+
+	trie = NewTrie(WithDisplayForms(AllForms))
+	trie.Insert("Apple")
+	trie.Insert("APPLE")
+	trie.Insert("apricot")
+
+	da = trie.Compile()
+
+	verifyOrderedMatches(t, da.Like("a", -1), "Apple", "APPLE", "apricot")
+	verifyOrderedMatches(t, da.Like("a", 2), "Apple", "APPLE")
+}
+
+func BenchmarkContainsNodeTree(b *testing.B) {
+
+	trie := NewTrie()
+	words := benchmarkWords()
+	for _, w := range words {
+		trie.Insert(w)
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		trie.Contains(words[i%len(words)])
+	}
+}
+
+func BenchmarkContainsDoubleArray(b *testing.B) {
+
+	trie := NewTrie()
+	words := benchmarkWords()
+	for _, w := range words {
+		trie.Insert(w)
+	}
+
+	da := trie.Compile()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		da.Contains(words[i%len(words)])
+	}
+}
+
+func BenchmarkLikeNodeTree(b *testing.B) {
+
+	trie := NewTrie()
+	for _, w := range benchmarkWords() {
+		trie.Insert(w)
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		trie.Like("ab", 10)
+	}
+}
+
+func BenchmarkLikeDoubleArray(b *testing.B) {
+
+	trie := NewTrie()
+	for _, w := range benchmarkWords() {
+		trie.Insert(w)
+	}
+
+	da := trie.Compile()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		da.Like("ab", 10)
+	}
+}
@@ -0,0 +1,146 @@
+package trie
+
+// FuzzyMatch is a word found by LikeFuzzy, along with the number of edits
+// needed to turn the searched prefix into a prefix of the word
+type FuzzyMatch struct {
+	Word     string
+	Distance int
+}
+
+// LikeFuzzy will find words that start with something within maxEdits insertions, deletions or substitutions
+// of the prefix, up to the supplied count. The closest matches are returned first, and matches at the same
+// distance are ordered alphabetically.
+func (t *Trie) LikeFuzzy(prefix string, maxEdits int, count int) []FuzzyMatch {
+	return t.Snapshot().LikeFuzzy(prefix, maxEdits, count)
+}
+
+// LikeFuzzy finds the words in the Snapshot that start with something close to the prefix, as Trie.LikeFuzzy does
+func (s *Snapshot) LikeFuzzy(prefix string, maxEdits int, count int) []FuzzyMatch {
+
+	if len(prefix) == 0 || maxEdits < 0 {
+		return make([]FuzzyMatch, 0)
+	}
+
+	return s.displayMatches(likeFuzzy(s.children, s.split(prefix), maxEdits, count), count)
+}
+
+// displayMatches replaces the word of each match with the spellings the display
+// policy picks, up to the supplied count
+func (s *Snapshot) displayMatches(matches []FuzzyMatch, count int) []FuzzyMatch {
+
+	if s.display == 0 {
+		return matches
+	}
+
+	display := make([]FuzzyMatch, 0, len(matches))
+	for _, m := range matches {
+		for _, w := range s.displayWords([]string{m.Word}, -1) {
+			if count >= 0 && len(display) >= count {
+				return display
+			}
+			display = append(display, FuzzyMatch{Word: w, Distance: m.Distance})
+		}
+	}
+
+	return display
+}
+
+func likeFuzzy(rootChildren []*node, prefix []rune, maxEdits int, count int) []FuzzyMatch {
+
+	matches := make([]FuzzyMatch, 0)
+
+	if count == 0 {
+		return matches
+	}
+
+	// the first row is the distance from each prefix of the prefix to an empty word
+	row := make([]int, len(prefix)+1)
+	for i := range row {
+		row[i] = i
+	}
+
+	// each pass finds the words at exactly one distance in alphabetical order, so the
+	// closest matches come first and the search stops as soon as there are enough
+	for distance := 0; distance <= maxEdits; distance++ {
+		for _, c := range rootChildren {
+			if !findFuzzy(c, prefix, nil, row, row[len(prefix)], distance, count, &matches) {
+				return matches
+			}
+		}
+	}
+
+	return matches
+}
+
+// findFuzzy computes the rows of the Levenshtein table for each rune in the value
+// of n, and descends into its children as long as a match at exactly the distance
+// is still possible. best is the smallest distance between the prefix and any
+// prefix of the word so far. It returns false once count matches have been found.
+func findFuzzy(n *node, prefix []rune, word []rune, row []int, best int, distance int, count int, matches *[]FuzzyMatch) bool {
+
+	word = append(word, n.value...)
+
+	for _, r := range n.value {
+
+		var closest int
+
+		row, closest = nextRow(prefix, row, r)
+		best = smallest(best, row[len(prefix)])
+
+		// every word from here on is closer, so an earlier pass found it
+		if best < distance {
+			return true
+		}
+
+		// no descendant can get any closer than the closest cell in this row
+		if best > distance && closest > distance {
+			return true
+		}
+	}
+
+	if n.endOfWord && best == distance {
+		*matches = append(*matches, FuzzyMatch{Word: string(word), Distance: best})
+		if count > 0 && len(*matches) >= count {
+			return false
+		}
+	}
+
+	for _, c := range n.children {
+		if !findFuzzy(c, prefix, word, row, best, distance, count, matches) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// nextRow computes the row of the Levenshtein table after the rune r is added to
+// the word, along with the smallest distance in that row
+func nextRow(prefix []rune, previous []int, r rune) ([]int, int) {
+
+	row := make([]int, len(previous))
+	row[0] = previous[0] + 1
+	closest := row[0]
+
+	for i := 1; i < len(row); i++ {
+		substitution := previous[i-1]
+		if prefix[i-1] != r {
+			substitution++
+		}
+
+		row[i] = smallest(previous[i]+1, row[i-1]+1, substitution)
+		closest = smallest(closest, row[i])
+	}
+
+	return row, closest
+}
+
+func smallest(first int, rest ...int) int {
+	for _, v := range rest {
+		if v < first {
+			first = v
+		}
+	}
+
+	return first
+}
@@ -0,0 +1,92 @@
+package trie
+
+import "testing"
+
+func TestLikeFuzzyEmptyPrefix(t *testing.T) {
+
+	trie := NewTrie()
+	for _, w := range wordsLike {
+		trie.Insert(w)
+	}
+
+	verifyFuzzyMatches(t, trie.LikeFuzzy("", 1, 5))
+}
+
+func TestLikeFuzzyExactPrefix(t *testing.T) {
+
+	trie := NewTrie()
+	for _, w := range wordsLike {
+		trie.Insert(w)
+	}
+
+	verifyFuzzyMatches(t, trie.LikeFuzzy("aa", 0, -1),
+		FuzzyMatch{"aachen", 0}, FuzzyMatch{"aaron", 0}, FuzzyMatch{"aaronite", 0})
+}
+
+func TestLikeFuzzyTransposedRunes(t *testing.T) {
+
+	trie := NewTrie()
+	for _, w := range wordsLike {
+		trie.Insert(w)
+	}
+
+	verifyFuzzyMatches(t, trie.LikeFuzzy("aaorn", 2, -1),
+		FuzzyMatch{"aaron", 2}, FuzzyMatch{"aaronite", 2})
+}
+
+func TestLikeFuzzyOrdersByDistance(t *testing.T) {
+
+	trie := NewTrie()
+	trie.Insert("cart")
+	trie.Insert("cat")
+	trie.Insert("cut")
+	trie.Insert("dog")
+
+	verifyFuzzyMatches(t, trie.LikeFuzzy("cat", 1, -1),
+		FuzzyMatch{"cat", 0}, FuzzyMatch{"cart", 1}, FuzzyMatch{"cut", 1})
+}
+
+func TestLikeFuzzyCount(t *testing.T) {
+
+	trie := NewTrie()
+	trie.Insert("cart")
+	trie.Insert("cat")
+	trie.Insert("cut")
+
+	verifyFuzzyMatches(t, trie.LikeFuzzy("cat", 1, 2), FuzzyMatch{"cat", 0}, FuzzyMatch{"cart", 1})
+	verifyFuzzyMatches(t, trie.LikeFuzzy("cat", 1, 0))
+}
+
+func TestLikeFuzzyTooManyEdits(t *testing.T) {
+
+	trie := NewTrie()
+	trie.Insert("dog")
+
+	verifyFuzzyMatches(t, trie.LikeFuzzy("cat", 2, -1))
+	verifyFuzzyMatches(t, trie.LikeFuzzy("cat", -1, -1))
+}
+
+func verifyFuzzyMatches(t *testing.T, actual []FuzzyMatch, expected ...FuzzyMatch) {
+	if len(actual) != len(expected) {
+		t.Fatalf("There should be %v matches but found %v", len(expected), actual)
+	}
+
+	for i, m := range expected {
+		if actual[i] != m {
+			t.Errorf("match %v should be %v but found %v", i, m, actual[i])
+		}
+	}
+}
+
+func TestLikeFuzzyCountStopsAtClosest(t *testing.T) {
+
+	trie := NewTrie()
+	trie.Insert("bat")
+	trie.Insert("cab")
+	trie.Insert("cat")
+	trie.Insert("cats")
+	trie.Insert("cot")
+
+	verifyFuzzyMatches(t, trie.LikeFuzzy("cat", 1, 3),
+		FuzzyMatch{"cat", 0}, FuzzyMatch{"cats", 0}, FuzzyMatch{"bat", 1})
+}
@@ -0,0 +1,208 @@
+package trie
+
+import (
+	"iter"
+	"strings"
+)
+
+// Iterator walks the words in a Trie in alphabetical order, one word at a time.
+// The Trie may be modified between calls to Next. When that happens the
+// Iterator carries on from the word after the current one, and may or may not
+// see words inserted or removed since it started. An Iterator over a Snapshot
+// always sees the same words. Once Next reports there are no more words it
+// keeps doing so, even if words are inserted after the end, until Seek is
+// called.
+//
+// An Iterator is not safe for concurrent use by multiple goroutines.
+type Iterator struct {
+	trie     *Trie
+	snapshot *Snapshot
+	cursor   cursor
+	current  string
+	started  bool
+	done     bool
+	seek     []rune
+}
+
+// cursor is a depth first walk through the tree that can be stopped after any
+// word, and started again from any key
+type cursor struct {
+	stack []frame
+	word  []rune
+}
+
+// frame is a level of the walk, with the children of the node at the end of
+// word[:depth] and the index of the next child to visit
+type frame struct {
+	children []*node
+	index    int
+	depth    int
+}
+
+// Iterator returns an Iterator positioned before the first word in the Trie
+func (t *Trie) Iterator() *Iterator {
+
+	it := &Iterator{trie: t}
+	it.Seek("")
+
+	return it
+}
+
+// Iterator returns an Iterator positioned before the first word in the Snapshot
+func (s *Snapshot) Iterator() *Iterator {
+
+	it := &Iterator{snapshot: s}
+	it.Seek("")
+
+	return it
+}
+
+// Seek positions the Iterator so that the next call to Next moves to the first word that is equal to or after
+// the key in alphabetical order
+func (it *Iterator) Seek(key string) {
+
+	if it.trie != nil {
+		it.snapshot = it.trie.Snapshot()
+	}
+
+	it.seek = it.snapshot.split(key)
+	it.started, it.done = false, false
+	it.current = ""
+	it.cursor.position(it.snapshot.children, it.seek, true)
+}
+
+// Next moves the Iterator to the next word, and reports whether there was one
+func (it *Iterator) Next() bool {
+
+	if it.done {
+		return false
+	}
+
+	if it.trie != nil {
+		if latest := it.trie.Snapshot(); latest.version != it.snapshot.version {
+			// the words changed since the stack was built, so find our place again in the latest tree
+			it.snapshot = latest
+			if it.started {
+				it.cursor.position(it.snapshot.children, []rune(it.current), false)
+			} else {
+				it.cursor.position(it.snapshot.children, it.seek, true)
+			}
+		}
+	}
+
+	word, _, ok := it.cursor.next()
+	if !ok {
+		it.current, it.done = "", true
+		return false
+	}
+
+	it.current, it.started = string(word), true
+
+	return true
+}
+
+// Word returns the word the Iterator is positioned on
+func (it *Iterator) Word() string {
+	return it.current
+}
+
+// next moves the cursor to the next word, and returns the word with the node that ends it
+func (c *cursor) next() ([]rune, *node, bool) {
+
+	for len(c.stack) > 0 {
+
+		f := &c.stack[len(c.stack)-1]
+		if f.index >= len(f.children) {
+			c.stack = c.stack[:len(c.stack)-1]
+			continue
+		}
+
+		n := f.children[f.index]
+		f.index++
+
+		c.word = append(c.word[:f.depth], n.value...)
+		c.stack = append(c.stack, frame{children: n.children, depth: len(c.word)})
+
+		if n.endOfWord {
+			return c.word, n, true
+		}
+	}
+
+	return nil, nil, false
+}
+
+// position rebuilds the stack so that the walk continues from the first word
+// after the key, or from the key itself when inclusive is set and it is stored
+func (c *cursor) position(rootChildren []*node, key []rune, inclusive bool) {
+
+	c.word = c.word[:0]
+	c.stack = append(c.stack[:0], frame{children: rootChildren})
+
+	for rest := key; len(rest) > 0; {
+
+		f := &c.stack[len(c.stack)-1]
+
+		index, n := search(f.children, rest[0])
+		f.index = index
+		if n == nil {
+			// every child from the index onwards comes after the key
+			return
+		}
+
+		common := commonPrefix(n.value, rest)
+
+		switch {
+		case common == len(rest) && (common < len(n.value) || inclusive):
+			// the key is a prefix of every word from n onwards
+			return
+		case common < len(rest) && common < len(n.value):
+			// the key leaves the edge part way along, so n is either all before or all after it
+			if n.value[common] < rest[common] {
+				f.index++
+			}
+			return
+		}
+
+		// n and the key share the whole edge, and n's own word is not after the key
+		f.index++
+		c.word = append(c.word, n.value...)
+		c.stack = append(c.stack, frame{children: n.children, depth: len(c.word)})
+		rest = rest[common:]
+	}
+}
+
+// All returns an iterator over every word in the Trie in alphabetical order
+func (t *Trie) All() iter.Seq[string] {
+	return t.WithPrefix("")
+}
+
+// WithPrefix returns an iterator over the words that start with the prefix in alphabetical order
+func (t *Trie) WithPrefix(prefix string) iter.Seq[string] {
+	return withPrefix(t.Iterator, string(t.split(prefix)))
+}
+
+// All returns an iterator over every word in the Snapshot in alphabetical order
+func (s *Snapshot) All() iter.Seq[string] {
+	return s.WithPrefix("")
+}
+
+// WithPrefix returns an iterator over the words in the Snapshot that start with the prefix in alphabetical order
+func (s *Snapshot) WithPrefix(prefix string) iter.Seq[string] {
+	return withPrefix(s.Iterator, string(s.split(prefix)))
+}
+
+// withPrefix yields the words from a new Iterator that start with the normalized prefix
+func withPrefix(iterator func() *Iterator, prefix string) iter.Seq[string] {
+
+	return func(yield func(string) bool) {
+
+		it := iterator()
+		it.Seek(prefix)
+
+		for it.Next() {
+			if !strings.HasPrefix(it.Word(), prefix) || !yield(it.Word()) {
+				return
+			}
+		}
+	}
+}
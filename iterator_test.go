@@ -0,0 +1,171 @@
+package trie
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestIteratorOnEmptyTrie(t *testing.T) {
+
+	it := NewTrie().Iterator()
+
+	if it.Next() {
+		t.Errorf("iterator should not find any words; found %v", it.Word())
+	}
+}
+
+func TestIteratorVisitsEveryWord(t *testing.T) {
+
+	trie := NewTrie()
+	for _, w := range wordsReverseAlphabet {
+		trie.Insert(w)
+	}
+	trie.Insert("alp")
+	trie.Insert("alphabet")
+
+	words := make([]string, 0)
+	for it := trie.Iterator(); it.Next(); {
+		words = append(words, it.Word())
+	}
+
+	expected := append([]string{"alp", "alpha", "alphabet"}, wordsAlphabet[1:]...)
+	verifyOrderedMatches(t, words, expected...)
+}
+
+func TestIteratorSeek(t *testing.T) {
+
+	trie := NewTrie()
+	for _, w := range wordsLike {
+		trie.Insert(w)
+	}
+
+	tests := []struct {
+		key   string
+		first string
+	}{
+		{"", "aachen"},
+		{"aaron", "aaron"},
+		{"AARON", "aaron"},
+		{"aaro", "aaron"},
+		{"aaronb", "aaronite"},
+		{"aaronz", "abaciscus"},
+		{"abdn", "abdomen"},
+		{"abdp", "abduce"},
+		{"ab", "abaciscus"},
+		{"0", "aachen"},
+		{"abednego", "abednego"},
+	}
+
+	for _, test := range tests {
+
+		it := trie.Iterator()
+		it.Seek(test.key)
+
+		if !it.Next() || it.Word() != test.first {
+			t.Errorf("seeking %v should find %v; found %v", test.key, test.first, it.Word())
+		}
+	}
+
+	it := trie.Iterator()
+	it.Seek("abf")
+	if it.Next() {
+		t.Errorf("seeking past the last word should find nothing; found %v", it.Word())
+	}
+}
+
+func TestIteratorResumesAfterChanges(t *testing.T) {
+
+	trie := NewTrie()
+	trie.Insert("alpha")
+	trie.Insert("bravo")
+	trie.Insert("charlie")
+
+	it := trie.Iterator()
+	if !it.Next() || it.Word() != "alpha" {
+		t.Fatalf("iterator should start at alpha; found %v", it.Word())
+	}
+
+	trie.Remove("alpha")
+	trie.Remove("bravo")
+	trie.Insert("beta")
+	trie.Insert("aardvark")
+
+	words := make([]string, 0)
+	for it.Next() {
+		words = append(words, it.Word())
+	}
+
+	verifyOrderedMatches(t, words, "beta", "charlie")
+}
+
+func TestIteratorStaysDoneAfterChanges(t *testing.T) {
+
+	trie := NewTrie()
+	trie.Insert("a")
+	trie.Insert("b")
+
+	it := trie.Iterator()
+	for it.Next() {
+	}
+
+	trie.Insert("c")
+
+	if it.Next() {
+		t.Fatalf("a finished iterator should not find %v", it.Word())
+	}
+
+	it.Seek("b")
+
+	words := make([]string, 0)
+	for it.Next() {
+		words = append(words, it.Word())
+	}
+
+	verifyOrderedMatches(t, words, "b", "c")
+}
+
+func TestAll(t *testing.T) {
+
+	trie := NewTrie()
+	for _, w := range wordsReverseAlphabet {
+		trie.Insert(w)
+	}
+
+	verifyOrderedMatches(t, slices.Collect(trie.All()), wordsAlphabet...)
+}
+
+func TestWithPrefix(t *testing.T) {
+
+	trie := NewTrie()
+	for _, w := range wordsLike {
+		trie.Insert(w)
+	}
+
+	verifyOrderedMatches(t, slices.Collect(trie.WithPrefix("abd")), trie.Like("abd", -1)...)
+	verifyOrderedMatches(t, slices.Collect(trie.WithPrefix("ABDOM")), "abdomen", "abdominal", "abdominocentesis")
+	verifyMatches(t, slices.Collect(trie.WithPrefix("b")))
+
+	words := make([]string, 0)
+	for w := range trie.WithPrefix("a") {
+		if len(words) == 2 {
+			break
+		}
+		words = append(words, w)
+	}
+
+	verifyOrderedMatches(t, words, "aachen", "aaron")
+}
+
+func TestWithPrefixAllowsInsertsWhileIterating(t *testing.T) {
+
+	trie := NewTrie()
+	trie.Insert("a")
+
+	for w := range trie.All() {
+		if len(w) < 3 {
+			trie.Insert(w + "a")
+		}
+	}
+
+	verifyOrderedMatches(t, slices.Collect(trie.All()), "a", "aa", "aaa")
+}
@@ -0,0 +1,121 @@
+package trie
+
+import "sync"
+
+// Map is a Trie that associates a value with every key it stores. Keys follow
+// the same rules as words in a Trie: they are stored as lowercase and an empty
+// key is never stored.
+type Map[V any] struct {
+	count    int
+	children []*node
+	lock     sync.RWMutex
+}
+
+// Entry is a key stored in a Map along with its value
+type Entry[V any] struct {
+	Key   string
+	Value V
+}
+
+// NewMap initializes the Map
+func NewMap[V any]() *Map[V] {
+	return &Map[V]{}
+}
+
+// Count returns the number of keys currently stored in the Map
+func (m *Map[V]) Count() int {
+
+	m.lock.RLock()
+	c := m.count
+	m.lock.RUnlock()
+
+	return c
+}
+
+// Put stores the value under the key, replacing any value already stored there
+func (m *Map[V]) Put(key string, value V) {
+
+	// If key has a zero length, do nothing
+	if len(key) == 0 {
+		return
+	}
+
+	runes := splitWord(key)
+
+	m.lock.Lock()
+	c, n, inserted := insert(m.children, runes)
+	if inserted {
+		m.count++
+	}
+	m.children = c
+	n.edit().data = value
+	m.lock.Unlock()
+}
+
+// Get returns the value stored under the key, and whether the key was found
+func (m *Map[V]) Get(key string) (V, bool) {
+
+	var value V
+
+	if len(key) == 0 {
+		return value, false
+	}
+
+	m.lock.RLock()
+	found, n := contains(m.children, splitWord(key))
+	if found {
+		// a nil interface value is stored as nil data, which only the comma-ok form turns back into V
+		value, _ = n.data().(V)
+	}
+	m.lock.RUnlock()
+
+	return value, found
+}
+
+// Delete will remove a key and its value if it exists, and do nothing if it does not exist
+func (m *Map[V]) Delete(key string) {
+	if len(key) == 0 {
+		return
+	}
+
+	m.lock.Lock()
+	if c, removed := remove(m.children, splitWord(key)); removed {
+		m.children = c
+		m.count--
+	}
+	m.lock.Unlock()
+}
+
+// Like returns the entries whose keys start with the prefix in alphabetical order, up to the supplied count
+func (m *Map[V]) Like(prefix string, count int) []Entry[V] {
+
+	entries := make([]Entry[V], 0)
+
+	if len(prefix) == 0 || count == 0 {
+		return entries
+	}
+
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	endOfPrefix, word := complete(m.children, splitWord(prefix))
+	if endOfPrefix == nil {
+		return entries
+	}
+
+	collect := func(key []rune, n *node) bool {
+		value, _ := n.data().(V)
+		entries = append(entries, Entry[V]{Key: string(key), Value: value})
+		return count < 0 || len(entries) < count
+	}
+
+	if endOfPrefix.endOfWord {
+		collect(word, endOfPrefix)
+	}
+
+	if count < 0 || len(entries) < count {
+		walk(endOfPrefix, word, collect)
+	}
+
+	return entries
+}
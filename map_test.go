@@ -0,0 +1,165 @@
+package trie
+
+import "testing"
+
+func TestMapCreate(t *testing.T) {
+	m := NewMap[int]()
+
+	if m.Count() != 0 {
+		t.Error("map should have zero keys after creation")
+	}
+}
+
+func TestMapPutEmptyKey(t *testing.T) {
+	m := NewMap[int]()
+
+	m.Put("", 1)
+
+	if m.Count() != 0 {
+		t.Error("map should not store an empty key")
+	}
+
+	if _, found := m.Get(""); found {
+		t.Error("map should not contain an empty key")
+	}
+}
+
+func TestMapPutAndGet(t *testing.T) {
+	m := NewMap[int]()
+
+	m.Put("foo", 1)
+	m.Put("foobar", 2)
+
+	if m.Count() != 2 {
+		t.Error("map should have two keys")
+	}
+
+	if v, found := m.Get("foo"); !found || v != 1 {
+		t.Errorf("foo should map to 1; found %v", v)
+	}
+
+	if v, found := m.Get("FOOBAR"); !found || v != 2 {
+		t.Errorf("foobar should map to 2; found %v", v)
+	}
+
+	if _, found := m.Get("fo"); found {
+		t.Error("map should not contain fo")
+	}
+}
+
+func TestMapPutReplacesValue(t *testing.T) {
+	m := NewMap[string]()
+
+	m.Put("key", "first")
+	m.Put("key", "second")
+
+	if m.Count() != 1 {
+		t.Error("map should have one key")
+	}
+
+	if v, _ := m.Get("key"); v != "second" {
+		t.Errorf("key should map to second; found %v", v)
+	}
+}
+
+func TestMapDelete(t *testing.T) {
+	m := NewMap[int]()
+
+	m.Put("foo", 1)
+	m.Put("foobar", 2)
+	m.Delete("foo")
+	m.Delete("missing")
+
+	if m.Count() != 1 {
+		t.Error("map should have one key")
+	}
+
+	if _, found := m.Get("foo"); found {
+		t.Error("map should not contain foo")
+	}
+
+	if v, found := m.Get("foobar"); !found || v != 2 {
+		t.Errorf("foobar should map to 2; found %v", v)
+	}
+}
+
+func TestMapDeleteClearsValue(t *testing.T) {
+	m := NewMap[*int]()
+
+	one := 1
+	m.Put("ab", &one)
+	m.Put("abc", nil)
+	m.Put("abd", nil)
+	m.Delete("ab")
+
+	_, n := contains(m.children, []rune("ab"))
+	if n.data() != nil {
+		t.Error("deleted key should not keep its value")
+	}
+}
+
+func TestMapLike(t *testing.T) {
+	m := NewMap[int]()
+	for i, w := range wordsLike {
+		m.Put(w, i)
+	}
+
+	entries := m.Like("aa", -1)
+
+	expected := []Entry[int]{{"aachen", 0}, {"aaron", 1}, {"aaronite", 2}}
+	if len(entries) != len(expected) {
+		t.Fatalf("There should be %v entries but found %v", len(expected), len(entries))
+	}
+
+	for i, e := range expected {
+		if entries[i] != e {
+			t.Errorf("entry %v should be %v but found %v", i, e, entries[i])
+		}
+	}
+}
+
+func TestMapLikeCount(t *testing.T) {
+	m := NewMap[int]()
+	m.Put("foo", 1)
+	m.Put("foobar", 2)
+	m.Put("foobaz", 3)
+
+	if entries := m.Like("foo", 2); len(entries) != 2 || entries[0].Key != "foo" || entries[1].Key != "foobar" {
+		t.Errorf("foo should match foo and foobar; found %v", entries)
+	}
+
+	if entries := m.Like("foo", 0); len(entries) != 0 {
+		t.Errorf("there should be no entries; found %v", entries)
+	}
+
+	if entries := m.Like("", -1); len(entries) != 0 {
+		t.Errorf("there should be no entries; found %v", entries)
+	}
+}
+
+func TestMapNilInterfaceValues(t *testing.T) {
+
+	m := NewMap[any]()
+	m.Put("a", nil)
+	m.Put("ab", 1)
+
+	if v, found := m.Get("a"); !found || v != nil {
+		t.Errorf("a should be found with a nil value; found %v, %v", v, found)
+	}
+
+	entries := m.Like("a", -1)
+	if len(entries) != 2 || entries[0].Value != nil || entries[1].Value != 1 {
+		t.Errorf("like should return a with a nil value and ab with 1; found %v", entries)
+	}
+
+	errs := NewMap[error]()
+	errs.Put("none", nil)
+
+	if err, found := errs.Get("none"); !found || err != nil {
+		t.Errorf("none should be found with a nil error; found %v, %v", err, found)
+	}
+
+	if entries := errs.Like("no", -1); len(entries) != 1 || entries[0].Value != nil {
+		t.Errorf("like should return none with a nil error; found %v", entries)
+	}
+}
@@ -0,0 +1,255 @@
+package trie
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// scoredJSON is how a word with a score is represented in JSON
+type scoredJSON struct {
+	Word  string  `json:"word"`
+	Score float64 `json:"score"`
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler using the format written by WriteTo
+func (t *Trie) MarshalBinary() ([]byte, error) {
+	return t.Snapshot().MarshalBinary()
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler, as Trie.MarshalBinary does
+func (s *Snapshot) MarshalBinary() ([]byte, error) {
+
+	var buf bytes.Buffer
+	if _, err := s.WriteTo(&buf); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler, replacing the contents of the Trie
+func (t *Trie) UnmarshalBinary(data []byte) error {
+
+	r := bytes.NewReader(data)
+	d := newDecoder(r)
+
+	count, children, err := d.trie(&t.settings)
+	if err != nil {
+		return err
+	}
+
+	if r.Len() > 0 {
+		return fmt.Errorf("%w: %v bytes after the checksum", ErrFormat, r.Len())
+	}
+
+	t.swap(count, children)
+
+	return nil
+}
+
+// MarshalText implements encoding.TextMarshaler, writing the words in alphabetical order separated by newlines.
+// Scores are not included.
+func (t *Trie) MarshalText() ([]byte, error) {
+	return t.Snapshot().MarshalText()
+}
+
+// MarshalText implements encoding.TextMarshaler, as Trie.MarshalText does
+func (s *Snapshot) MarshalText() ([]byte, error) {
+
+	var buf bytes.Buffer
+
+	walkAll(s.children, func(word []rune, _ *node) bool {
+		if buf.Len() > 0 {
+			buf.WriteByte('\n')
+		}
+		buf.WriteString(string(word))
+		return true
+	})
+
+	return buf.Bytes(), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler, replacing the contents of the Trie with the words on each
+// line of the text. Blank lines are ignored.
+func (t *Trie) UnmarshalText(text []byte) error {
+
+	words := make([]scoredJSON, 0)
+	for _, line := range strings.Split(string(text), "\n") {
+		words = append(words, scoredJSON{Word: strings.TrimSuffix(line, "\r")})
+	}
+
+	t.replace(words)
+
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler, writing the words as an array in alphabetical order. When any word has
+// a score, each word is written as an object with "word" and "score" fields instead.
+func (t *Trie) MarshalJSON() ([]byte, error) {
+	return t.Snapshot().MarshalJSON()
+}
+
+// MarshalJSON implements json.Marshaler, as Trie.MarshalJSON does
+func (s *Snapshot) MarshalJSON() ([]byte, error) {
+
+	words := make([]scoredJSON, 0)
+	scored := false
+
+	walkAll(s.children, func(word []rune, n *node) bool {
+		words = append(words, scoredJSON{Word: string(word), Score: n.score()})
+		scored = scored || n.score() != 0
+		return true
+	})
+
+	if scored {
+		return json.Marshal(words)
+	}
+
+	plain := make([]string, len(words))
+	for i, w := range words {
+		plain[i] = w.Word
+	}
+
+	return json.Marshal(plain)
+}
+
+// UnmarshalJSON implements json.Unmarshaler, replacing the contents of the Trie with the words in a JSON array.
+// The array may hold strings, objects with "word" and "score" fields, or a mix of both.
+func (t *Trie) UnmarshalJSON(data []byte) error {
+
+	var elements []json.RawMessage
+	if err := json.Unmarshal(data, &elements); err != nil {
+		return err
+	}
+
+	words := make([]scoredJSON, len(elements))
+	for i, e := range elements {
+
+		if err := json.Unmarshal(e, &words[i].Word); err == nil {
+			continue
+		}
+
+		if err := json.Unmarshal(e, &words[i]); err != nil {
+			return err
+		}
+	}
+
+	t.replace(words)
+
+	return nil
+}
+
+// replace builds a new tree from the words before swapping it in, so readers
+// never see the Trie part way through being replaced
+func (t *Trie) replace(words []scoredJSON) {
+
+	var count int
+	children := make([]*node, 0)
+
+	for _, w := range words {
+
+		if len(w.Word) == 0 {
+			continue
+		}
+
+		c, n, inserted := insert(children, t.split(w.Word))
+		if n == nil {
+			continue
+		}
+
+		children = c
+		if inserted {
+			count++
+		}
+
+		// only words with a score need a payload to hold it
+		if w.Score != 0 || n.score() != 0 {
+			n.edit().score = w.Score
+		}
+		if t.display != 0 {
+			n.addForm(w.Word)
+		}
+	}
+
+	t.swap(count, children)
+}
+
+// MarshalJSON implements json.Marshaler, writing the Map as an object with its keys in alphabetical order
+func (m *Map[V]) MarshalJSON() ([]byte, error) {
+
+	var buf bytes.Buffer
+	var err error
+
+	buf.WriteByte('{')
+
+	m.lock.RLock()
+	walkAll(m.children, func(key []rune, n *node) bool {
+
+		var k, v []byte
+		if k, err = json.Marshal(string(key)); err != nil {
+			return false
+		}
+
+		if v, err = json.Marshal(n.data()); err != nil {
+			return false
+		}
+
+		if buf.Len() > 1 {
+			buf.WriteByte(',')
+		}
+
+		buf.Write(k)
+		buf.WriteByte(':')
+		buf.Write(v)
+		return true
+	})
+	m.lock.RUnlock()
+
+	if err != nil {
+		return nil, err
+	}
+
+	buf.WriteByte('}')
+
+	return buf.Bytes(), nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler, replacing the contents of the Map with the keys and values in a
+// JSON object. When several keys normalize to the same key, the value of the one that sorts last is kept.
+func (m *Map[V]) UnmarshalJSON(data []byte) error {
+
+	var entries map[string]V
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return err
+	}
+
+	var count int
+	children := make([]*node, 0)
+
+	// the keys are inserted in order so that keys that collide always keep the same value
+	for _, key := range slices.Sorted(maps.Keys(entries)) {
+
+		if len(key) == 0 {
+			continue
+		}
+
+		c, n, inserted := insert(children, splitWord(key))
+
+		children = c
+		if inserted {
+			count++
+		}
+
+		n.edit().data = entries[key]
+	}
+
+	m.lock.Lock()
+	m.count, m.children = count, children
+	m.lock.Unlock()
+
+	return nil
+}
@@ -0,0 +1,219 @@
+package trie
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"testing"
+)
+
+func TestMarshalBinary(t *testing.T) {
+
+	trie := NewTrie()
+	for _, w := range wordsLike {
+		trie.Insert(w)
+	}
+	trie.InsertWeighted("abby", 3)
+
+	data, err := trie.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	loaded := NewTrie()
+	if err := loaded.UnmarshalBinary(data); err != nil {
+		t.Fatal(err)
+	}
+
+	verifyOrderedMatches(t, loaded.Like("a", -1), trie.Like("a", -1)...)
+	verifyOrderedMatches(t, loaded.LikeTopK("ab", 1), "abby")
+
+	if err := loaded.UnmarshalBinary(append(data, 0)); err == nil {
+		t.Error("trailing data should return an error")
+	}
+}
+
+func TestGobUsesBinaryMarshaler(t *testing.T) {
+
+	type cached struct {
+		Name  string
+		Words *Trie
+	}
+
+	trie := NewTrie()
+	trie.Insert("foo")
+	trie.Insert("foobar")
+
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(cached{Name: "words", Words: trie}); err != nil {
+		t.Fatal(err)
+	}
+
+	var loaded cached
+	if err := gob.NewDecoder(&buf).Decode(&loaded); err != nil {
+		t.Fatal(err)
+	}
+
+	verifyOrderedMatches(t, loaded.Words.Like("f", -1), "foo", "foobar")
+}
+
+func TestMarshalText(t *testing.T) {
+
+	trie := NewTrie()
+	trie.Insert("foobar")
+	trie.Insert("foo")
+	trie.Insert("bar")
+
+	text, err := trie.MarshalText()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(text) != "bar\nfoo\nfoobar" {
+		t.Errorf("text should be each word on its own line; found %q", text)
+	}
+
+	loaded := NewTrie()
+	loaded.Insert("replaced")
+	if err := loaded.UnmarshalText([]byte("Foo\r\n\nfoobar\nbar\n")); err != nil {
+		t.Fatal(err)
+	}
+
+	if loaded.Count() != 3 || loaded.Contains("replaced") {
+		t.Errorf("trie should only contain the three words in the text")
+	}
+
+	verifyOrderedMatches(t, loaded.Like("f", -1), "foo", "foobar")
+}
+
+func TestMarshalJSONWithoutScores(t *testing.T) {
+
+	trie := NewTrie()
+	trie.Insert("foobar")
+	trie.Insert("foo")
+
+	data, err := json.Marshal(trie)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(data) != `["foo","foobar"]` {
+		t.Errorf("json should be an array of words; found %s", data)
+	}
+
+	loaded := NewTrie()
+	if err := json.Unmarshal(data, loaded); err != nil {
+		t.Fatal(err)
+	}
+
+	verifyOrderedMatches(t, loaded.Like("f", -1), "foo", "foobar")
+}
+
+func TestMarshalJSONWithScores(t *testing.T) {
+
+	trie := NewTrie()
+	trie.Insert("foo")
+	trie.InsertWeighted("foobar", 1.5)
+
+	data, err := json.Marshal(trie)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(data) != `[{"word":"foo","score":0},{"word":"foobar","score":1.5}]` {
+		t.Errorf("json should be an array of scored words; found %s", data)
+	}
+
+	loaded := NewTrie()
+	if err := json.Unmarshal([]byte(`["foo",{"word":"foobar","score":1.5}]`), loaded); err != nil {
+		t.Fatal(err)
+	}
+
+	verifyOrderedMatches(t, loaded.LikeTopK("f", -1), "foobar", "foo")
+
+	if err := json.Unmarshal([]byte(`[1]`), loaded); err == nil {
+		t.Error("a number should not unmarshal as a word")
+	}
+}
+
+func TestMapMarshalJSON(t *testing.T) {
+
+	m := NewMap[int]()
+	m.Put("foobar", 2)
+	m.Put("foo", 1)
+	m.Put("bar", 3)
+
+	data, err := json.Marshal(m)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(data) != `{"bar":3,"foo":1,"foobar":2}` {
+		t.Errorf("json should be an object of keys and values; found %s", data)
+	}
+
+	loaded := NewMap[int]()
+	loaded.Put("replaced", 0)
+	if err := json.Unmarshal(data, loaded); err != nil {
+		t.Fatal(err)
+	}
+
+	if loaded.Count() != 3 {
+		t.Errorf("map should have three keys; found %v", loaded.Count())
+	}
+
+	if v, _ := loaded.Get("foobar"); v != 2 {
+		t.Errorf("foobar should map to 2; found %v", v)
+	}
+
+	if _, found := loaded.Get("replaced"); found {
+		t.Error("map should not contain replaced")
+	}
+}
+
+func TestEmptyMapMarshalJSON(t *testing.T) {
+
+	data, err := json.Marshal(NewMap[int]())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(data) != `{}` {
+		t.Errorf("json should be an empty object; found %s", data)
+	}
+}
+
+func TestMapUnmarshalJSONNull(t *testing.T) {
+
+	m := NewMap[any]()
+	if err := json.Unmarshal([]byte(`{"a":null,"b":2}`), m); err != nil {
+		t.Fatal(err)
+	}
+
+	if v, found := m.Get("a"); !found || v != nil {
+		t.Errorf("a should be found with a nil value; found %v, %v", v, found)
+	}
+}
+
+func TestMapUnmarshalJSONCollidingKeys(t *testing.T) {
+
+	for i := 0; i < 10; i++ {
+
+		m := NewMap[int]()
+		if err := json.Unmarshal([]byte(`{"a":2,"A":1,"b":3,"B":4}`), m); err != nil {
+			t.Fatal(err)
+		}
+
+		if v, _ := m.Get("a"); v != 2 {
+			t.Fatalf("a should keep the value of the key that sorts last; found %v", v)
+		}
+
+		if v, _ := m.Get("b"); v != 3 {
+			t.Fatalf("b should keep the value of the key that sorts last; found %v", v)
+		}
+
+		if m.Count() != 2 {
+			t.Fatalf("map should have two keys; found %v", m.Count())
+		}
+	}
+}
@@ -0,0 +1,104 @@
+package trie
+
+const (
+	// matchOne matches exactly one rune in a pattern
+	matchOne = '?'
+
+	// matchAny matches any run of runes in a pattern, including none
+	matchAny = '*'
+)
+
+// Match will find the words that match a pattern, up to the supplied count. A '?' in the pattern matches exactly
+// one rune and a '*' matches any number of runes. Matches are returned in alphabetical order.
+func (t *Trie) Match(pattern string, count int) []string {
+	return t.Snapshot().Match(pattern, count)
+}
+
+// Match finds the words in the Snapshot that match a pattern, as Trie.Match does
+func (s *Snapshot) Match(pattern string, count int) []string {
+
+	if len(pattern) == 0 {
+		return make([]string, 0)
+	}
+
+	return s.displayWords(match(s.children, s.split(pattern), count), count)
+}
+
+func match(rootChildren []*node, pattern []rune, count int) []string {
+
+	words := make([]string, 0)
+
+	if count == 0 {
+		return words
+	}
+
+	// the pattern is simulated as a set of positions that could be reached so far,
+	// which lets every child be visited once no matter how many '*' there are
+	start := make([]bool, len(pattern)+1)
+	start[0] = true
+	skipAny(pattern, start)
+
+	findMatches(rootChildren, pattern, start, nil, &words, count)
+
+	return words
+}
+
+func findMatches(nodes []*node, pattern []rune, positions []bool, word []rune, words *[]string, count int) {
+
+	for _, n := range nodes {
+
+		if count >= 0 && len(*words) >= count {
+			return
+		}
+
+		next, ok := positions, true
+		for i := 0; ok && i < len(n.value); i++ {
+			next, ok = advance(pattern, next, n.value[i])
+		}
+
+		if !ok {
+			continue
+		}
+
+		current := append(word, n.value...)
+		if n.endOfWord && next[len(pattern)] {
+			*words = append(*words, string(current))
+		}
+
+		findMatches(n.children, pattern, next, current, words, count)
+	}
+}
+
+// advance consumes the rune at every reachable position in the pattern, and
+// reports whether any position is still reachable afterwards
+func advance(pattern []rune, positions []bool, r rune) ([]bool, bool) {
+
+	var ok bool
+
+	next := make([]bool, len(positions))
+	for i, reached := range positions[:len(pattern)] {
+		if !reached {
+			continue
+		}
+
+		switch pattern[i] {
+		case matchAny:
+			next[i], ok = true, true
+		case matchOne, r:
+			next[i+1], ok = true, true
+		}
+	}
+
+	skipAny(pattern, next)
+
+	return next, ok
+}
+
+// skipAny marks the position after every reachable '*', since it may match no runes at all
+func skipAny(pattern []rune, positions []bool) {
+	for i, p := range pattern {
+		if positions[i] && p == matchAny {
+			positions[i+1] = true
+		}
+	}
+}
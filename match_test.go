@@ -0,0 +1,65 @@
+package trie
+
+import "testing"
+
+func TestMatchEmptyPattern(t *testing.T) {
+
+	trie := NewTrie()
+	for _, w := range wordsLike {
+		trie.Insert(w)
+	}
+
+	verifyMatches(t, trie.Match("", -1))
+}
+
+func TestMatchExactWord(t *testing.T) {
+
+	trie := NewTrie()
+	for _, w := range wordsLike {
+		trie.Insert(w)
+	}
+
+	verifyOrderedMatches(t, trie.Match("aaron", -1), "aaron")
+	verifyMatches(t, trie.Match("aaro", -1))
+}
+
+func TestMatchSingleRune(t *testing.T) {
+
+	trie := NewTrie()
+	trie.Insert("cat")
+	trie.Insert("cut")
+	trie.Insert("cart")
+	trie.Insert("at")
+
+	verifyOrderedMatches(t, trie.Match("c?t", -1), "cat", "cut")
+	verifyOrderedMatches(t, trie.Match("?at", -1), "cat")
+}
+
+func TestMatchAnyRunes(t *testing.T) {
+
+	trie := NewTrie()
+	for _, w := range wordsLike {
+		trie.Insert(w)
+	}
+
+	verifyOrderedMatches(t, trie.Match("a?ron*", -1), "aaron", "aaronite")
+	verifyOrderedMatches(t, trie.Match("*tor", -1), "abator", "abbreviator", "abdicator", "abductor")
+	verifyOrderedMatches(t, trie.Match("ab*o*", 3), "abaco", "abandonee", "abandonware")
+}
+
+func TestMatchDoesNotRepeatWords(t *testing.T) {
+
+	trie := NewTrie()
+	trie.Insert("banana")
+
+	verifyOrderedMatches(t, trie.Match("*a*a*", -1), "banana")
+	verifyOrderedMatches(t, trie.Match("**", -1), "banana")
+}
+
+func TestMatchZeroCount(t *testing.T) {
+
+	trie := NewTrie()
+	trie.Insert("banana")
+
+	verifyMatches(t, trie.Match("*", 0))
+}
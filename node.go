@@ -2,172 +2,326 @@ package trie
 
 import "sort"
 
+// node is the end of an edge in the tree. Chains of nodes that do not branch
+// and do not end a word are collapsed into a single node, so the value holds
+// every rune along the edge rather than a single rune.
+//
+// Nodes are never changed once they are part of a tree that may be read. Changes
+// copy the nodes along the path to the word instead, and share everything else
+// with the tree they were made from.
 type node struct {
-	value     rune
-	parent    *node
+	value     []rune
 	children  []*node
 	endOfWord bool
+
+	// payload is nil unless something is kept about the word ending on the node,
+	// so that nodes which only branch stay small
+	payload *payload
 }
 
-// create initializes a node with the value,
-// and passes in the next suffix that will be inserted into its children
-func create(r rune, suffix []rune, parent *node) (*node, bool) {
+// payload is what is kept about a word besides its runes
+type payload struct {
+	score float64
+	data  interface{}
+	forms []displayForm
+}
 
-	var inserted bool
+// edit returns the payload of n for changing. The payload may be shared with an
+// earlier copy of n, so n is given its own copy of it first.
+func (n *node) edit() *payload {
 
-	n := &node{
-		value:    r,
-		children: make([]*node, 0),
-		parent:   parent,
+	p := &payload{}
+	if n.payload != nil {
+		*p = *n.payload
 	}
+	n.payload = p
 
-	if len(suffix) > 0 {
-		n.children, inserted = insert(n.children, suffix, n)
-	} else {
-		n.endOfWord = true
+	return p
+}
+
+// score returns the score of the word ending on n
+func (n *node) score() float64 {
+	if n.payload == nil {
+		return 0
 	}
 
-	return n, inserted
+	return n.payload.score
 }
 
-func insert(nodes []*node, word []rune, parent *node) ([]*node, bool) {
+// data returns the value a Map stores under the key ending on n
+func (n *node) data() interface{} {
+	if n.payload == nil {
+		return nil
+	}
+
+	return n.payload.data
+}
+
+// forms returns the spellings the word ending on n was inserted with
+func (n *node) forms() []displayForm {
+	if n.payload == nil {
+		return nil
+	}
+
+	return n.payload.forms
+}
+
+// create initializes a node that ends a word with the remaining runes of that word as its value
+func create(word []rune) *node {
+	return &node{
+		value:     append([]rune{}, word...),
+		children:  make([]*node, 0),
+		endOfWord: true,
+	}
+}
+
+// clone returns a copy of n that can be changed without changing n. The copy
+// shares its value and children with n.
+func (n *node) clone() *node {
+	c := *n
+	return &c
+}
+
+// insert returns a copy of the nodes with the word added, along with the node
+// the word ends on and whether the word was not already there. The nodes along
+// the path to the word are copies, so the node returned may be changed until
+// the tree is read.
+func insert(nodes []*node, word []rune) ([]*node, *node, bool) {
+
+	// a word may normalize to nothing, and there is nowhere to store it
+	if len(word) == 0 {
+		return nodes, nil, false
+	}
 
+	index, n := search(nodes, word[0])
+	if n == nil {
+		created := create(word)
+		return insertChild(nodes, index, created), created, true
+	}
+
+	c := n.clone()
+
+	common := commonPrefix(c.value, word)
+	if common < len(c.value) {
+		// the word leaves the edge part way along, so the edge needs a node there
+		split(c, common)
+	}
+
+	var end *node
 	var inserted bool
 
-	prefix, suffix := word[0], word[1:]
-
-	index, n := search(nodes, prefix)
-	if n != nil {
-		if len(suffix) > 0 {
-			n.children, inserted = insert(n.children, suffix, n)
-		} else if n.endOfWord {
-			// we just found the same word
-			inserted = false
-		} else {
-			inserted, n.endOfWord = true, true
-		}
+	if suffix := word[common:]; len(suffix) > 0 {
+		c.children, end, inserted = insert(c.children, suffix)
 	} else {
+		// the word is already there unless the node did not end a word
+		end, inserted = c, !c.endOfWord
+		c.endOfWord = true
+	}
 
-		nodeToInsert, _ := create(prefix, suffix, parent)
+	return replaceChild(nodes, index, c), end, inserted
+}
 
-		if index == len(nodes) {
-			// If the new node should be on the end, just append it
-			nodes = append(nodes, nodeToInsert)
-		} else {
-			// Otherwise insert the node at the appropriate index
-			nodes = append(nodes, nil)
-			copy(nodes[index+1:], nodes[index:])
-			nodes[index] = nodeToInsert
-		}
+// split shortens the value of n to its first runes, and moves the rest of the
+// value along with everything n held into a new child
+func split(n *node, at int) {
+
+	tail := &node{
+		value:     n.value[at:],
+		children:  n.children,
+		endOfWord: n.endOfWord,
+		payload:   n.payload,
+	}
 
-		inserted = true
+	// limit the capacity so a later merge cannot append over the tail's runes
+	n.value = n.value[:at:at]
+	n.children = []*node{tail}
+	n.endOfWord, n.payload = false, nil
+}
+
+// merge folds the only child of n into n, once n no longer needs to end a word
+func merge(n *node) {
+
+	child := n.children[0]
+
+	value := make([]rune, 0, len(n.value)+len(child.value))
+	n.value = append(append(value, n.value...), child.value...)
+	n.children = child.children
+	n.endOfWord, n.payload = child.endOfWord, child.payload
+}
+
+// commonPrefix returns how many leading runes a and b have in common
+func commonPrefix(a []rune, b []rune) int {
+	i := 0
+	for i < len(a) && i < len(b) && a[i] == b[i] {
+		i++
 	}
 
-	return nodes, inserted
+	return i
 }
 
-// contains recursively searches children until it reaches the end of the word,
-// or it cannot find a node matching the rune
+// locate recursively searches children until it reaches the end of the word, or
+// it cannot follow the word any further. It returns the node whose value holds the
+// last rune of the word, and how many runes of that value the word used.
+func locate(nodes []*node, word []rune) (*node, int) {
+
+	if len(word) == 0 {
+		return nil, 0
+	}
+
+	// Search for the node that starts with the rune
+	_, n := search(nodes, word[0])
+	if n == nil {
+		return nil, 0
+	}
+
+	common := commonPrefix(n.value, word)
+
+	// if the word ends on this edge, we don't search anymore children
+	if common == len(word) {
+		return n, common
+	}
+
+	// the word left the edge before reaching the node
+	if common < len(n.value) {
+		return nil, 0
+	}
+
+	// recursively search the children
+	return locate(n.children, word[common:])
+}
+
+// contains reports whether the word is stored, along with the node the word ends
+// on. The node is nil when the word does not end exactly on a node.
 func contains(nodes []*node, word []rune) (bool, *node) {
 
-	// Search for the node that matches the rune
-	_, node := search(nodes, word[0])
-	if node == nil {
+	n, used := locate(nodes, word)
+	if n == nil || used < len(n.value) {
 		return false, nil
 	}
 
-	// if this is the last rune in the word, we don't search anymore children
-	if endOfWord := len(word) == 1; endOfWord {
-		if node.endOfWord {
-			return true, node
-		}
-		return false, node
+	return n.endOfWord, n
+}
+
+// complete returns the first node below which every word starts with the prefix,
+// along with the runes leading to that node
+func complete(nodes []*node, prefix []rune) (*node, []rune) {
+
+	n, used := locate(nodes, prefix)
+	if n == nil {
+		return nil, nil
 	}
 
-	// recursively search the children
-	return contains(node.children, word[1:])
+	word := make([]rune, 0, len(prefix)+len(n.value)-used)
+	word = append(append(word, prefix...), n.value[used:]...)
+
+	return n, word
 }
 
-// search looks for the node where the value matches the rune
+// search looks for the node where the value starts with the rune
 func search(nodes []*node, r rune) (int, *node) {
-	index := sort.Search(len(nodes), func(i int) bool { return nodes[i].value >= r })
-	if index >= 0 && index < len(nodes) && nodes[index].value == r {
+	index := sort.Search(len(nodes), func(i int) bool { return nodes[i].value[0] >= r })
+	if index >= 0 && index < len(nodes) && nodes[index].value[0] == r {
 		return index, nodes[index]
 	}
 
 	return index, nil
 }
 
-func remove(rootChildren []*node, word []rune) ([]*node, bool) {
+// remove returns a copy of the nodes without the word, and whether the word was there
+func remove(nodes []*node, word []rune) ([]*node, bool) {
 
-	found, n := contains(rootChildren, word)
+	if len(word) == 0 {
+		return nodes, false
+	}
 
-	if found {
+	index, n := search(nodes, word[0])
+	if n == nil {
+		return nodes, false
+	}
 
-		n.endOfWord = false
+	common := commonPrefix(n.value, word)
+	if common < len(n.value) {
+		return nodes, false
+	}
 
-		c := cleanup(n, n.parent)
+	if common < len(word) {
 
-		if c != nil {
-			// delete the child from the parent's children
-			return deleteChild(rootChildren, c), found
+		children, removed := remove(n.children, word[common:])
+		if !removed {
+			return nodes, false
 		}
-	}
 
-	return rootChildren, found
-}
+		c := n.clone()
+		c.children = children
 
-// cleanup remove a node from the parent if that node has no children
-func cleanup(n *node, p *node) *node {
+		// the node may have been left as a chain with a single child
+		if !c.endOfWord && len(c.children) == 1 {
+			merge(c)
+		}
 
-	// when p is nil, it means n is a root child and we need to remove it
-	if p == nil {
-		return n
+		return replaceChild(nodes, index, c), true
 	}
 
-	// if n has children, cleanup is done
-	if len(n.children) > 0 {
-		return nil
+	if !n.endOfWord {
+		return nodes, false
 	}
 
-	// delete the child from the parent's children
-	p.children = deleteChild(p.children, n)
+	if len(n.children) == 0 {
+		// the node is a leaf, so delete it from the children
+		return deleteChild(nodes, index), true
+	}
 
-	// if the parent is the end of another word, cleanup is done
-	if p.endOfWord {
-		return nil
+	c := &node{value: n.value, children: n.children}
+	if len(c.children) == 1 {
+		// the node only joins its parent to its child now
+		merge(c)
 	}
 
-	// recurse up the tree to determine if the parent needs to be deleted
-	return cleanup(p, p.parent)
+	return replaceChild(nodes, index, c), true
 }
 
-func deleteChild(children []*node, child *node) []*node {
-	if i, c := search(children, child.value); c == child {
+// insertChild returns a copy of the children with the child added at the index
+func insertChild(children []*node, index int, child *node) []*node {
 
-		// remove the child c at index i, and preserve order
-		copy(children[i:], children[i+1:])
-		children[len(children)-1] = nil
-		children = children[:len(children)-1]
-	}
+	copied := make([]*node, len(children)+1)
+	copy(copied, children[:index])
+	copied[index] = child
+	copy(copied[index+1:], children[index:])
+
+	return copied
+}
+
+// replaceChild returns a copy of the children with the child at the index replaced
+func replaceChild(children []*node, index int, child *node) []*node {
+
+	copied := append(make([]*node, 0, len(children)), children...)
+	copied[index] = child
 
-	return children
+	return copied
+}
+
+// deleteChild returns a copy of the children without the child at the index, preserving order
+func deleteChild(children []*node, index int) []*node {
+
+	copied := make([]*node, 0, len(children)-1)
+
+	return append(append(copied, children[:index]...), children[index+1:]...)
 }
 
 func like(rootChildren []*node, prefix []rune, count int) []string {
 
 	words := make([]string, 0)
 
-	_, endOfPrefix := contains(rootChildren, prefix)
+	endOfPrefix, word := complete(rootChildren, prefix)
 	if endOfPrefix == nil {
 		return words
 	}
 
 	if endOfPrefix.endOfWord {
-		words = append(words, string(prefix))
+		words = append(words, string(word))
 	}
 
-	findWords(endOfPrefix, string(prefix), &words, "", count)
+	findWords(endOfPrefix, string(word), &words, "", count)
 
 	return words
 }
@@ -188,3 +342,27 @@ func findWords(n *node, prefix string, words *[]string, parent string, count int
 		findWords(c, prefix, words, current, count)
 	}
 }
+
+// walk visits every word below n in rune order, calling fn with the word and
+// the node that terminates it. The walk stops as soon as fn returns false.
+func walk(n *node, word []rune, fn func([]rune, *node) bool) bool {
+
+	for _, c := range n.children {
+
+		current := append(word, c.value...)
+		if c.endOfWord && !fn(current, c) {
+			return false
+		}
+
+		if !walk(c, current, fn) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// walkAll visits every word in the tree with the root children, as walk does
+func walkAll(rootChildren []*node, fn func([]rune, *node) bool) bool {
+	return walk(&node{children: rootChildren}, nil, fn)
+}
@@ -2,13 +2,13 @@ package trie
 
 import (
 	"testing"
+	"unsafe"
 )
 
 type nodeExpectation struct {
-	value     rune
-	children  []rune
-	nextChild rune
-	parent    rune
+	value     string
+	children  []string
+	nextChild string
 	endOfWord bool
 }
 
@@ -19,12 +19,10 @@ func TestSingleWordShouldBeInserted(t *testing.T) {
 
 	actual := root[0]
 	expectations := []nodeExpectation{
-		{value: 'f', children: []rune{'u'}, nextChild: 'u'},
-		{value: 'u', children: []rune{'n'}, nextChild: 'n', parent: 'f'},
-		{value: 'n', parent: 'u', endOfWord: true},
+		{value: "fun", endOfWord: true},
 	}
 
-	verifyExpectations(t, actual, expectations, 0, "f")
+	verifyExpectations(t, actual, expectations, 0, "fun")
 }
 
 func TestOverlappingWordsShouldBeInserted(t *testing.T) {
@@ -35,14 +33,26 @@ func TestOverlappingWordsShouldBeInserted(t *testing.T) {
 
 	actual := root[0]
 	expectations := []nodeExpectation{
-		{value: 'f', children: []rune{'u'}, nextChild: 'u'},
-		{value: 'u', children: []rune{'n'}, nextChild: 'n', parent: 'f'},
-		{value: 'n', children: []rune{'n'}, nextChild: 'n', parent: 'u', endOfWord: true},
-		{value: 'n', children: []rune{'y'}, nextChild: 'y', parent: 'n'},
-		{value: 'y', parent: 'n', endOfWord: true},
+		{value: "fun", children: []string{"ny"}, nextChild: "ny", endOfWord: true},
+		{value: "ny", endOfWord: true},
 	}
 
-	verifyExpectations(t, actual, expectations, 0, "f")
+	verifyExpectations(t, actual, expectations, 0, "fun")
+}
+
+func TestUnderlappingWordsShouldSplitNode(t *testing.T) {
+
+	root := make([]*node, 0)
+	root = insertWordAndVerify(t, root, "funny", 1)
+	root = insertWordAndVerify(t, root, "fun", 1)
+
+	actual := root[0]
+	expectations := []nodeExpectation{
+		{value: "fun", children: []string{"ny"}, nextChild: "ny", endOfWord: true},
+		{value: "ny", endOfWord: true},
+	}
+
+	verifyExpectations(t, actual, expectations, 0, "fun")
 }
 
 func TestNodesWhenWordsAreInsertedOutOfOrder(t *testing.T) {
@@ -56,29 +66,13 @@ func TestNodesWhenWordsAreInsertedOutOfOrder(t *testing.T) {
 	beta := root[1]
 	gamma := root[2]
 
-	alphaExpectations := []nodeExpectation{
-		{value: 'a', children: []rune{'l'}, nextChild: 'l'},
-		{value: 'l', children: []rune{'p'}, nextChild: 'p', parent: 'a'},
-		{value: 'p', children: []rune{'h'}, nextChild: 'h', parent: 'l'},
-		{value: 'h', children: []rune{'a'}, nextChild: 'a', parent: 'p'},
-		{value: 'a', parent: 'h', endOfWord: true}}
-
-	betaExpectations := []nodeExpectation{
-		{value: 'b', children: []rune{'e'}, nextChild: 'e'},
-		{value: 'e', children: []rune{'t'}, nextChild: 't', parent: 'b'},
-		{value: 't', children: []rune{'a'}, nextChild: 'a', parent: 'e'},
-		{value: 'a', parent: 't', endOfWord: true}}
-
-	gammaExpectations := []nodeExpectation{
-		{value: 'g', children: []rune{'a'}, nextChild: 'a'},
-		{value: 'a', children: []rune{'m'}, nextChild: 'm', parent: 'g'},
-		{value: 'm', children: []rune{'m'}, nextChild: 'm', parent: 'a'},
-		{value: 'm', children: []rune{'a'}, nextChild: 'a', parent: 'm'},
-		{value: 'a', parent: 'm', endOfWord: true}}
-
-	verifyExpectations(t, alpha, alphaExpectations, 0, "a")
-	verifyExpectations(t, beta, betaExpectations, 0, "b")
-	verifyExpectations(t, gamma, gammaExpectations, 0, "g")
+	alphaExpectations := []nodeExpectation{{value: "alpha", endOfWord: true}}
+	betaExpectations := []nodeExpectation{{value: "beta", endOfWord: true}}
+	gammaExpectations := []nodeExpectation{{value: "gamma", endOfWord: true}}
+
+	verifyExpectations(t, alpha, alphaExpectations, 0, "alpha")
+	verifyExpectations(t, beta, betaExpectations, 0, "beta")
+	verifyExpectations(t, gamma, gammaExpectations, 0, "gamma")
 }
 
 func TestNodesWhenInsertingForkedWords(t *testing.T) {
@@ -91,30 +85,37 @@ func TestNodesWhenInsertingForkedWords(t *testing.T) {
 	actual := root[0]
 
 	crazyExpectations := []nodeExpectation{
-		{value: 'c', children: []rune{'r'}, nextChild: 'r'},
-		{value: 'r', children: []rune{'a', 'e'}, nextChild: 'a', parent: 'c'},
-		{value: 'a', children: []rune{'y', 'z'}, nextChild: 'z', parent: 'r'},
-		{value: 'z', children: []rune{'y'}, nextChild: 'y', parent: 'a'},
-		{value: 'y', parent: 'z', endOfWord: true}}
+		{value: "cr", children: []string{"a", "eam"}, nextChild: "a"},
+		{value: "a", children: []string{"yon", "zy"}, nextChild: "zy"},
+		{value: "zy", endOfWord: true}}
 
 	crayonExpectations := []nodeExpectation{
-		{value: 'c', children: []rune{'r'}, nextChild: 'r'},
-		{value: 'r', children: []rune{'a', 'e'}, nextChild: 'a', parent: 'c'},
-		{value: 'a', children: []rune{'y', 'z'}, nextChild: 'y', parent: 'r'},
-		{value: 'y', children: []rune{'o'}, nextChild: 'o', parent: 'a'},
-		{value: 'o', children: []rune{'n'}, nextChild: 'n', parent: 'y'},
-		{value: 'n', parent: 'o', endOfWord: true}}
+		{value: "cr", children: []string{"a", "eam"}, nextChild: "a"},
+		{value: "a", children: []string{"yon", "zy"}, nextChild: "yon"},
+		{value: "yon", endOfWord: true}}
 
 	creamExpectations := []nodeExpectation{
-		{value: 'c', children: []rune{'r'}, nextChild: 'r'},
-		{value: 'r', children: []rune{'a', 'e'}, nextChild: 'e', parent: 'c'},
-		{value: 'e', children: []rune{'a'}, nextChild: 'a', parent: 'r'},
-		{value: 'a', children: []rune{'m'}, nextChild: 'm', parent: 'e'},
-		{value: 'm', parent: 'a', endOfWord: true}}
-
-	verifyExpectations(t, actual, crazyExpectations, 0, "c")
-	verifyExpectations(t, actual, crayonExpectations, 0, "c")
-	verifyExpectations(t, actual, creamExpectations, 0, "c")
+		{value: "cr", children: []string{"a", "eam"}, nextChild: "eam"},
+		{value: "eam", endOfWord: true}}
+
+	verifyExpectations(t, actual, crazyExpectations, 0, "cr")
+	verifyExpectations(t, actual, crayonExpectations, 0, "cr")
+	verifyExpectations(t, actual, creamExpectations, 0, "cr")
+}
+
+func TestSplitKeepsWordBelowNode(t *testing.T) {
+
+	root := make([]*node, 0)
+	root = insertWordAndVerify(t, root, "abcd", 1)
+	root = insertWordAndVerify(t, root, "abcdef", 1)
+	root = insertWordAndVerify(t, root, "abx", 1)
+
+	expectations := []nodeExpectation{
+		{value: "ab", children: []string{"cd", "x"}, nextChild: "cd"},
+		{value: "cd", children: []string{"ef"}, nextChild: "ef", endOfWord: true},
+		{value: "ef", endOfWord: true}}
+
+	verifyExpectations(t, root[0], expectations, 0, "ab")
 }
 
 func TestNodesAreRemovedWhenWordIsRemoved(t *testing.T) {
@@ -132,10 +133,9 @@ func TestNodesAreCorrectAfterRemovingOverlappingWord(t *testing.T) {
 	root = removeWordAndVerify(t, root, "abc", 1)
 
 	expectations := []nodeExpectation{
-		{value: 'a', children: []rune{'b'}, nextChild: 'b'},
-		{value: 'b', parent: 'a', endOfWord: true}}
+		{value: "ab", endOfWord: true}}
 
-	verifyExpectations(t, root[0], expectations, 0, "a")
+	verifyExpectations(t, root[0], expectations, 0, "ab")
 }
 
 func TestNodesAreCorrectAfterRemovingUnderlappingWord(t *testing.T) {
@@ -146,17 +146,101 @@ func TestNodesAreCorrectAfterRemovingUnderlappingWord(t *testing.T) {
 	root = removeWordAndVerify(t, root, "ab", 1)
 
 	expectations := []nodeExpectation{
-		{value: 'a', children: []rune{'b'}, nextChild: 'b'},
-		{value: 'b', children: []rune{'c'}, nextChild: 'c', parent: 'a'},
-		{value: 'c', parent: 'b', endOfWord: true}}
+		{value: "abc", endOfWord: true}}
+
+	verifyExpectations(t, root[0], expectations, 0, "abc")
+}
+
+func TestNodesAreMergedAfterRemovingForkedWord(t *testing.T) {
+
+	root := make([]*node, 0)
+	root = insertWordAndVerify(t, root, "crazy", 1)
+	root = insertWordAndVerify(t, root, "crayon", 1)
+	root = insertWordAndVerify(t, root, "cream", 1)
+	root = removeWordAndVerify(t, root, "cream", 1)
+	root = removeWordAndVerify(t, root, "crazy", 1)
+
+	expectations := []nodeExpectation{
+		{value: "crayon", endOfWord: true}}
+
+	verifyExpectations(t, root[0], expectations, 0, "crayon")
+}
+
+func TestMergedNodeKeepsChildren(t *testing.T) {
+
+	root := make([]*node, 0)
+	root = insertWordAndVerify(t, root, "ab", 1)
+	root = insertWordAndVerify(t, root, "abcd", 1)
+	root = insertWordAndVerify(t, root, "abcdx", 1)
+	root = insertWordAndVerify(t, root, "abcdy", 1)
+	root = removeWordAndVerify(t, root, "abcd", 1)
+	root = removeWordAndVerify(t, root, "ab", 1)
+
+	expectations := []nodeExpectation{
+		{value: "abcd", children: []string{"x", "y"}, nextChild: "y"},
+		{value: "y", endOfWord: true}}
 
-	verifyExpectations(t, root[0], expectations, 0, "a")
+	verifyExpectations(t, root[0], expectations, 0, "abcd")
+}
+
+func TestLikeWhenPrefixEndsPartWayAlongNode(t *testing.T) {
+
+	root := make([]*node, 0)
+	root = insertWordAndVerify(t, root, "abdomen", 1)
+	root = insertWordAndVerify(t, root, "abdominal", 1)
+
+	words := like(root, []rune("ab"), -1)
+	if len(words) != 2 || words[0] != "abdomen" || words[1] != "abdominal" {
+		t.Errorf("ab should be like abdomen and abdominal; found %v", words)
+	}
+
+	if found, _ := contains(root, []rune("abd")); found {
+		t.Error("abd should not be found")
+	}
+}
+
+func TestInsertDoesNotChangeOriginalNodes(t *testing.T) {
+
+	before := make([]*node, 0)
+	before = insertWordAndVerify(t, before, "crazy", 1)
+	before = insertWordAndVerify(t, before, "cream", 1)
+
+	after := insertWordAndVerify(t, before, "crayon", 1)
+
+	expectations := []nodeExpectation{
+		{value: "cr", children: []string{"azy", "eam"}, nextChild: "azy"},
+		{value: "azy", endOfWord: true}}
+
+	verifyExpectations(t, before[0], expectations, 0, "cr")
+
+	if before[0] == after[0] {
+		t.Error("the node along the path to the new word should have been copied")
+	}
+
+	if before[0].children[1] != after[0].children[1] {
+		t.Error("the node for cream should be shared by both trees")
+	}
+}
+
+func TestRemoveDoesNotChangeOriginalNodes(t *testing.T) {
+
+	before := make([]*node, 0)
+	before = insertWordAndVerify(t, before, "crazy", 1)
+	before = insertWordAndVerify(t, before, "cream", 1)
+
+	after := removeWordAndVerify(t, before, "cream", 1)
+
+	if found, _ := contains(before, []rune("cream")); !found {
+		t.Error("cream should still be in the original tree")
+	}
+
+	verifyExpectations(t, after[0], []nodeExpectation{{value: "crazy", endOfWord: true}}, 0, "crazy")
 }
 
 func insertWordAndVerify(t *testing.T, root []*node, word string, expectedLen int) []*node {
 	var inserted bool
 
-	root, inserted = insert(root, []rune(word), nil)
+	root, _, inserted = insert(root, []rune(word))
 
 	if !inserted {
 		t.Errorf("%v should have been inserted and was not", word)
@@ -193,17 +277,16 @@ func verifyExpectations(t *testing.T, node *node, expectations []nodeExpectation
 	expect := expectations[index]
 
 	validateValue(t, expect, node, prefix)
-	validateParent(t, expect, node, prefix)
 	validateChildren(t, expect, node, prefix)
 	validateEndOfWord(t, expect, node, prefix)
 
-	if expect.nextChild != 0 {
+	if expect.nextChild != "" {
 
 		if nextNode := getNextNode(node.children, expect.nextChild); nextNode != nil {
 			index++
 			verifyExpectations(t, nextNode, expectations, index, prefix+string(nextNode.value))
 		} else {
-			t.Errorf("[%v] cannot verify next node '%c' because it does not exist", prefix, expect.nextChild)
+			t.Errorf("[%v] cannot verify next node '%v' because it does not exist", prefix, expect.nextChild)
 		}
 
 	}
@@ -211,37 +294,20 @@ func verifyExpectations(t *testing.T, node *node, expectations []nodeExpectation
 
 func validateValue(t *testing.T, e nodeExpectation, n *node, prefix string) {
 
-	if value := n.value; value != e.value {
-		t.Errorf("[%v] value must be '%c'; found %c", prefix, e.value, value)
-	}
-}
-
-func validateParent(t *testing.T, e nodeExpectation, n *node, prefix string) {
-
-	if e.parent != 0 && n.parent.value != e.parent {
-		t.Errorf("[%v] node should have parent '%c', found '%c'", prefix, e.parent, n.parent.value)
-	}
-
-	if e.parent == 0 && n.parent != nil {
-		t.Errorf("[%v] node should not have parent", prefix)
+	if value := string(n.value); value != e.value {
+		t.Errorf("[%v] value must be '%v'; found %v", prefix, e.value, value)
 	}
 }
 
 func validateChildren(t *testing.T, e nodeExpectation, n *node, prefix string) {
 
 	if len(e.children) != len(n.children) {
-		t.Errorf("[%v] node should have ", prefix)
+		t.Fatalf("[%v] node should have %v children; found %v", prefix, len(e.children), len(n.children))
 	}
 
-	for i, r := range e.children {
-		if n.children[i].value != r {
-			t.Errorf("[%v] node should have a child '%c' at index %v and does not", prefix, r, i)
-		}
-	}
-
-	for i, c := range n.children {
-		if e.children[i] != c.value {
-			t.Errorf("[%v] node has a child '%c' at index %v but this child is not expected", prefix, c.value, i)
+	for i, v := range e.children {
+		if string(n.children[i].value) != v {
+			t.Errorf("[%v] node should have a child '%v' at index %v and does not", prefix, v, i)
 		}
 	}
 }
@@ -257,12 +323,42 @@ func validateEndOfWord(t *testing.T, e nodeExpectation, n *node, prefix string)
 	}
 }
 
-func getNextNode(children []*node, r rune) *node {
+func getNextNode(children []*node, value string) *node {
 	for _, c := range children {
-		if c.value == r {
+		if string(c.value) == value {
 			return c
 		}
 	}
 
 	return nil
 }
+
+func TestNodesOnlyHoldPayloadsForWordsThatNeedThem(t *testing.T) {
+
+	if size := unsafe.Sizeof(node{}); size > 64 {
+		t.Errorf("node should take at most 64 bytes; takes %v", size)
+	}
+
+	trie := NewTrie()
+	trie.Insert("foo")
+	trie.Insert("foobar")
+	trie.Insert("foobaz")
+
+	for _, w := range []string{"foo", "fooba", "foobar"} {
+		if _, n := contains(trie.Snapshot().children, []rune(w)); n.payload != nil {
+			t.Errorf("%v should not have a payload", w)
+		}
+	}
+
+	trie.InsertWeighted("foo", 2)
+	before := trie.Snapshot()
+	trie.InsertWeighted("foo", 3)
+
+	if _, n := contains(before.children, []rune("foo")); n.score() != 2 {
+		t.Errorf("an earlier snapshot should keep the score of foo; found %v", n.score())
+	}
+
+	if _, n := contains(trie.Snapshot().children, []rune("foo")); n.score() != 3 {
+		t.Errorf("foo should have the new score; found %v", n.score())
+	}
+}
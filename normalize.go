@@ -0,0 +1,117 @@
+package trie
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+)
+
+// Normalizer turns a word into the form it is stored and matched in. Two words
+// are treated as the same word when they normalize to the same string.
+type Normalizer interface {
+	Normalize(word string) string
+}
+
+// NormalizerFunc lets an ordinary function be used as a Normalizer
+type NormalizerFunc func(word string) string
+
+// Normalize calls f(word)
+func (f NormalizerFunc) Normalize(word string) string {
+	return f(word)
+}
+
+var (
+	// Identity stores words exactly as they are inserted, so matching is case sensitive
+	Identity Normalizer = NormalizerFunc(func(word string) string { return word })
+
+	// Lowercase stores words in lowercase, and is what a Trie uses unless told otherwise
+	Lowercase Normalizer = NormalizerFunc(strings.ToLower)
+
+	// CaseFold stores words with Unicode simple case folding applied, so that every rune matches the runes it is
+	// equal to under case folding, such as 'K', 'k' and the Kelvin sign
+	CaseFold Normalizer = NormalizerFunc(foldCase)
+
+	// NFC stores words in Unicode Normalization Form C, so precomposed and decomposed accents match
+	NFC Normalizer = NormalizerFunc(norm.NFC.String)
+
+	// NFKC stores words in Unicode Normalization Form KC, which also matches compatibility forms such as
+	// ligatures and full width letters with their plain equivalents
+	NFKC Normalizer = NormalizerFunc(norm.NFKC.String)
+
+	// FoldDiacritics stores words with their accents and other combining marks removed, so "creme" matches
+	// "crème". Letters that do not decompose into a base letter and a mark, such as 'ø', are left as they are.
+	FoldDiacritics Normalizer = NormalizerFunc(foldDiacritics)
+)
+
+// Chain returns a Normalizer that applies each of the normalizers in turn
+func Chain(normalizers ...Normalizer) Normalizer {
+	return NormalizerFunc(func(word string) string {
+		for _, n := range normalizers {
+			word = n.Normalize(word)
+		}
+		return word
+	})
+}
+
+// WithAccentFolding matches words regardless of case or accents, while returning them spelled the way they were
+// first inserted, so searching for "zurich" finds "Zürich". A different DisplayPolicy can be chosen with
+// WithDisplayForms.
+func WithAccentFolding() Option {
+	return func(t *Trie) {
+		t.normalizer = Chain(FoldDiacritics, Lowercase)
+		if t.display == 0 {
+			t.display = FirstForm
+		}
+	}
+}
+
+// WithNormalizer sets how words are normalized before they are stored or searched for. The Trie uses Lowercase
+// when no Normalizer is supplied.
+func WithNormalizer(n Normalizer) Option {
+	return func(t *Trie) { t.normalizer = n }
+}
+
+// foldCase maps every rune to a single member of its case folding orbit, the
+// lowercase form of the smallest rune in the orbit
+func foldCase(word string) string {
+	return strings.Map(func(r rune) rune {
+		smallest := r
+		for f := unicode.SimpleFold(r); f != r; f = unicode.SimpleFold(f) {
+			if f < smallest {
+				smallest = f
+			}
+		}
+		return unicode.ToLower(smallest)
+	}, word)
+}
+
+// foldDiacritics decomposes the word, drops the combining marks and composes
+// what is left. Transformers keep state, so a new chain is needed for each word.
+func foldDiacritics(word string) string {
+
+	t := transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC)
+
+	folded, _, err := transform.String(t, word)
+	if err != nil {
+		return word
+	}
+
+	return folded
+}
+
+// split normalizes the word and breaks it into runes
+func (c *settings) split(word string) []rune {
+	return []rune(c.normalize(word))
+}
+
+// normalize returns the word in the form it is stored in
+func (c *settings) normalize(word string) string {
+	if c.normalizer == nil {
+		return strings.ToLower(word)
+	}
+
+	return c.normalizer.Normalize(word)
+}
@@ -0,0 +1,145 @@
+package trie
+
+import "testing"
+
+func TestDefaultNormalizerIsLowercase(t *testing.T) {
+
+	trie := NewTrie()
+	trie.Insert("FooBar")
+
+	if !trie.Contains("foobar") || !trie.Contains("FOOBAR") {
+		t.Error("trie should match foobar in any case")
+	}
+
+	verifyOrderedMatches(t, trie.Like("FOO", -1), "foobar")
+}
+
+func TestIdentityNormalizer(t *testing.T) {
+
+	trie := NewTrie(WithNormalizer(Identity))
+	trie.Insert("NewTrie")
+	trie.Insert("newTrie")
+
+	if trie.Count() != 2 {
+		t.Errorf("trie should have two words; found %v", trie.Count())
+	}
+
+	if !trie.Contains("NewTrie") || trie.Contains("newtrie") {
+		t.Error("trie should match case sensitively")
+	}
+
+	verifyOrderedMatches(t, trie.Like("New", -1), "NewTrie")
+
+	trie.Remove("newtrie")
+	trie.Remove("newTrie")
+	verifyOrderedMatches(t, trie.Like("n", -1))
+}
+
+func TestCaseFoldNormalizer(t *testing.T) {
+
+	trie := NewTrie(WithNormalizer(CaseFold))
+	trie.Insert("Kelvin")
+	trie.Insert("ſtraße")
+	trie.Insert("ΣΟΦΟΣ")
+
+	if !trie.Contains("kelvin") || !trie.Contains("KELVIN") {
+		t.Error("trie should match the Kelvin sign with k")
+	}
+
+	if !trie.Contains("STRAßE") || !trie.Contains("straße") {
+		t.Error("trie should match the long s with s")
+	}
+
+	if !trie.Contains("σοφος") || !trie.Contains("ΣΟΦΟς") {
+		t.Error("trie should match every form of sigma")
+	}
+}
+
+func TestNFCNormalizer(t *testing.T) {
+
+	trie := NewTrie(WithNormalizer(NFC))
+	trie.Insert("cre\u0300me")
+
+	if !trie.Contains("cr\u00e8me") {
+		t.Error("trie should match the precomposed accent with the decomposed one")
+	}
+
+	verifyOrderedMatches(t, trie.Like("cre\u0300", -1), "cr\u00e8me")
+}
+
+func TestNFKCNormalizer(t *testing.T) {
+
+	trie := NewTrie(WithNormalizer(Chain(NFKC, Lowercase)))
+	trie.Insert("ﬁnance")
+	trie.Insert("ＡＢＣ")
+
+	if !trie.Contains("finance") {
+		t.Error("trie should match the ligature with its letters")
+	}
+
+	if !trie.Contains("abc") {
+		t.Error("trie should match the full width letters with plain letters")
+	}
+}
+
+func TestNormalizerThatRemovesEverything(t *testing.T) {
+
+	nothing := NormalizerFunc(func(string) string { return "" })
+
+	trie := NewTrie(WithNormalizer(nothing))
+	trie.Insert("foo")
+	trie.InsertWeighted("foo", 1)
+	trie.Remove("foo")
+
+	if trie.Count() != 0 || trie.Contains("foo") {
+		t.Error("trie should not store a word that normalizes to nothing")
+	}
+
+	verifyMatches(t, trie.Like("foo", -1))
+}
+
+func TestNormalizerAppliesToIndexes(t *testing.T) {
+
+	trie := NewTrie(WithNormalizer(Identity), WithSuffixIndex(), WithSubstringIndex())
+	trie.Insert("Abductor")
+	trie.Insert("abductor")
+
+	verifyOrderedMatches(t, trie.EndsWith("Ductor", -1))
+	verifyOrderedMatches(t, trie.EndsWith("ductor", -1), "Abductor", "abductor")
+	verifyOrderedMatches(t, trie.Substring("Abd", -1), "Abductor")
+}
+
+func TestFoldDiacriticsNormalizer(t *testing.T) {
+
+	trie := NewTrie(WithNormalizer(FoldDiacritics))
+	trie.Insert("crème")
+	trie.Insert("Zürich")
+
+	if !trie.Contains("creme") || !trie.Contains("crême") {
+		t.Error("trie should match creme without accents")
+	}
+
+	verifyOrderedMatches(t, trie.Like("Zur", -1), "Zurich")
+}
+
+func TestAccentFolding(t *testing.T) {
+
+	trie := NewTrie(WithAccentFolding())
+	trie.Insert("Zürich")
+	trie.Insert("crème brûlée")
+	trie.Insert("Øresund")
+
+	verifyOrderedMatches(t, trie.Like("zurich", -1), "Zürich")
+	verifyOrderedMatches(t, trie.Like("CREME", -1), "crème brûlée")
+	verifyOrderedMatches(t, trie.Like("ø", -1), "Øresund")
+	verifyMatches(t, trie.Like("o", -1))
+}
+
+func TestAccentFoldingWithDisplayPolicy(t *testing.T) {
+
+	trie := NewTrie(WithDisplayForms(AllForms), WithAccentFolding())
+	trie.Insert("résumé")
+	trie.Insert("resume")
+
+	verifyOrderedMatches(t, trie.Like("res", -1), "résumé", "resume")
+}
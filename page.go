@@ -0,0 +1,68 @@
+package trie
+
+// LikeAfter will find the words that start with the prefix and come strictly after the supplied word in
+// alphabetical order, up to the supplied count. Passing the last word of one page as after returns the next page.
+// The display forms of a word are never split across pages, so a page ends with every form of its last word even
+// when that takes it past the count.
+func (t *Trie) LikeAfter(prefix string, after string, count int) []string {
+	return t.Snapshot().LikeAfter(prefix, after, count)
+}
+
+// LikeAfter finds the words in the Snapshot that start with the prefix and come after the supplied word, as
+// Trie.LikeAfter does
+func (s *Snapshot) LikeAfter(prefix string, after string, count int) []string {
+
+	if len(prefix) == 0 {
+		return make([]string, 0)
+	}
+
+	return s.displayPage(likeAfter(s.children, s.split(prefix), s.split(after), count), count)
+}
+
+// displayPage returns the display forms of the words, as displayWords does, but
+// finishes the forms of a word once it is started. The next page starts after
+// the normalized word, so any forms left off this page would never be seen.
+func (s *Snapshot) displayPage(words []string, count int) []string {
+
+	if s.display == 0 {
+		return words
+	}
+
+	display := make([]string, 0, len(words))
+	for _, w := range words {
+
+		if count >= 0 && len(display) >= count {
+			break
+		}
+
+		_, n := contains(s.children, []rune(w))
+		display = append(display, s.forms(w, n)...)
+	}
+
+	return display
+}
+
+func likeAfter(rootChildren []*node, prefix []rune, after []rune, count int) []string {
+
+	words := make([]string, 0)
+
+	// start from whichever of the prefix and the word comes later
+	var c cursor
+	if string(after) < string(prefix) {
+		c.position(rootChildren, prefix, true)
+	} else {
+		c.position(rootChildren, after, false)
+	}
+
+	for count < 0 || len(words) < count {
+
+		word, _, ok := c.next()
+		if !ok || commonPrefix(word, prefix) < len(prefix) {
+			break
+		}
+
+		words = append(words, string(word))
+	}
+
+	return words
+}
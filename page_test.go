@@ -0,0 +1,83 @@
+package trie
+
+import "testing"
+
+func TestLikeAfterEmptyPrefix(t *testing.T) {
+
+	trie := NewTrie()
+	for _, w := range wordsLike {
+		trie.Insert(w)
+	}
+
+	verifyMatches(t, trie.LikeAfter("", "aaron", 5))
+}
+
+func TestLikeAfterWithoutAfter(t *testing.T) {
+
+	trie := NewTrie()
+	for _, w := range wordsLike {
+		trie.Insert(w)
+	}
+
+	verifyOrderedMatches(t, trie.LikeAfter("abd", "", -1), trie.Like("abd", -1)...)
+}
+
+func TestLikeAfterPages(t *testing.T) {
+
+	trie := NewTrie()
+	for _, w := range wordsLike {
+		trie.Insert(w)
+	}
+
+	page := trie.LikeAfter("ab", "", 4)
+	verifyOrderedMatches(t, page, "abaciscus", "abaco", "abacterial", "abakan")
+
+	page = trie.LikeAfter("ab", page[len(page)-1], 4)
+	verifyOrderedMatches(t, page, "abandonee", "abandonware", "abatable", "abator")
+
+	page = trie.LikeAfter("abd", "abductee", 4)
+	verifyOrderedMatches(t, page, "abductor")
+
+	verifyMatches(t, trie.LikeAfter("abd", "abductor", 4))
+}
+
+func TestLikeAfterWordThatIsNotStored(t *testing.T) {
+
+	trie := NewTrie()
+	for _, w := range wordsLike {
+		trie.Insert(w)
+	}
+
+	verifyOrderedMatches(t, trie.LikeAfter("abd", "abdo", 2), "abdomen", "abdominal")
+	verifyOrderedMatches(t, trie.LikeAfter("abd", "abdz", -1))
+	verifyOrderedMatches(t, trie.LikeAfter("abd", "aaron", 1), "abdicator")
+}
+
+func TestLikeAfterWherePrefixIsWord(t *testing.T) {
+
+	trie := NewTrie()
+	trie.Insert("foo")
+	trie.Insert("foobar")
+	trie.Insert("fooz")
+
+	verifyOrderedMatches(t, trie.LikeAfter("foo", "", 1), "foo")
+	verifyOrderedMatches(t, trie.LikeAfter("foo", "foo", 1), "foobar")
+	verifyOrderedMatches(t, trie.LikeAfter("foo", "foobar", 5), "fooz")
+}
+
+func TestLikeAfterKeepsFormsTogether(t *testing.T) {
+
+	trie := NewTrie(WithDisplayForms(AllForms))
+	trie.Insert("Apple")
+	trie.Insert("APPLE")
+	trie.Insert("apricot")
+	trie.Insert("Avocado")
+
+	var pages []string
+	for page := trie.LikeAfter("a", "", 1); len(page) > 0; page = trie.LikeAfter("a", page[len(page)-1], 1) {
+		pages = append(pages, page...)
+	}
+
+	verifyOrderedMatches(t, pages, "Apple", "APPLE", "apricot", "Avocado")
+	verifyOrderedMatches(t, trie.LikeAfter("a", "", 1), "Apple", "APPLE")
+}
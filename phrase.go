@@ -0,0 +1,103 @@
+package trie
+
+import "unicode"
+
+// WithPhraseIndex keeps a tree of every token of every word, so that entries made of several words such as
+// "New York City" can be found by any of their words with LikePhrase. Tokens are the runs of letters and digits
+// in a word.
+func WithPhraseIndex() Option {
+	return func(t *Trie) { t.phraseIndex = true }
+}
+
+// LikePhrase will find the words that have a token starting with each of the tokens in the query, in
+// alphabetical order up to the supplied count, so "york" finds "New York City" and "city ka" finds "Kansas City".
+// It never finds any words unless the Trie was created with WithPhraseIndex.
+func (t *Trie) LikePhrase(query string, count int) []string {
+	return t.Snapshot().LikePhrase(query, count)
+}
+
+// LikePhrase finds the words in the Snapshot with a token starting with each token of the query, as
+// Trie.LikePhrase does
+func (s *Snapshot) LikePhrase(query string, count int) []string {
+
+	if len(query) == 0 || !s.phraseIndex {
+		return make([]string, 0)
+	}
+
+	return s.displayWords(likePhrase(s.tokens, tokenize(s.split(query)), count), count)
+}
+
+// likePhrase returns the words that own a token starting with every one of the query tokens
+func likePhrase(tokens []*node, query [][]rune, count int) []string {
+
+	if len(query) == 0 {
+		return make([]string, 0)
+	}
+
+	matches := ownerSet(tokens, query[0])
+	for _, q := range query[1:] {
+
+		if len(matches) == 0 {
+			break
+		}
+
+		next := ownerSet(tokens, q)
+		for w := range matches {
+			if _, found := next[w]; !found {
+				delete(matches, w)
+			}
+		}
+	}
+
+	return sortedWords(matches, count)
+}
+
+// indexTokens adds every distinct token of the word to the index, each owned by the word
+func indexTokens(index []*node, word []rune) []*node {
+	for _, token := range tokenize(word) {
+		index = indexKey(index, token, word)
+	}
+
+	return index
+}
+
+// unindexTokens removes the word from every one of its tokens in the index
+func unindexTokens(index []*node, word []rune) []*node {
+	for _, token := range tokenize(word) {
+		index = unindexKey(index, token, word)
+	}
+
+	return index
+}
+
+// tokenize splits the word into its distinct runs of letters and digits, in
+// the order they first appear
+func tokenize(word []rune) [][]rune {
+
+	tokens := make([][]rune, 0)
+	seen := make(wordSet)
+
+	start := -1
+	for i := 0; i <= len(word); i++ {
+
+		if i < len(word) && (unicode.IsLetter(word[i]) || unicode.IsDigit(word[i])) {
+			if start < 0 {
+				start = i
+			}
+			continue
+		}
+
+		if start < 0 {
+			continue
+		}
+
+		token := word[start:i]
+		if _, found := seen[string(token)]; !found {
+			seen[string(token)] = struct{}{}
+			tokens = append(tokens, token)
+		}
+		start = -1
+	}
+
+	return tokens
+}
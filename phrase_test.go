@@ -0,0 +1,85 @@
+package trie
+
+import "testing"
+
+func TestLikePhraseWithoutIndex(t *testing.T) {
+
+	trie := NewTrie()
+	trie.Insert("New York City")
+
+	verifyMatches(t, trie.LikePhrase("york", -1))
+}
+
+func TestLikePhrase(t *testing.T) {
+
+	trie := NewTrie(WithPhraseIndex())
+	trie.Insert("New York City")
+	trie.Insert("Kansas City")
+	trie.Insert("York")
+	trie.Insert("Yorkshire Dales")
+
+	verifyOrderedMatches(t, trie.LikePhrase("york", -1), "new york city", "york", "yorkshire dales")
+	verifyOrderedMatches(t, trie.LikePhrase("city ka", -1), "kansas city")
+	verifyOrderedMatches(t, trie.LikePhrase("City", -1), "kansas city", "new york city")
+	verifyOrderedMatches(t, trie.LikePhrase("new yo", -1), "new york city")
+	verifyOrderedMatches(t, trie.LikePhrase("ci", 1), "kansas city")
+	verifyMatches(t, trie.LikePhrase("york ka", -1))
+	verifyMatches(t, trie.LikePhrase("ity", -1))
+	verifyMatches(t, trie.LikePhrase(" ,", -1))
+	verifyMatches(t, trie.LikePhrase("", -1))
+}
+
+func TestLikePhrasePunctuation(t *testing.T) {
+
+	trie := NewTrie(WithPhraseIndex(), WithDisplayForms(FirstForm))
+	trie.Insert("Stratford-upon-Avon")
+	trie.Insert("St. Albans, Hertfordshire")
+
+	verifyOrderedMatches(t, trie.LikePhrase("avon", -1), "Stratford-upon-Avon")
+	verifyOrderedMatches(t, trie.LikePhrase("herts, st", -1))
+	verifyOrderedMatches(t, trie.LikePhrase("hert st", -1), "St. Albans, Hertfordshire")
+}
+
+func TestLikePhraseAfterRemove(t *testing.T) {
+
+	trie := NewTrie(WithPhraseIndex())
+	trie.Insert("new york city")
+	trie.Insert("kansas city")
+	trie.Insert("city of london")
+	trie.Remove("kansas city")
+
+	verifyOrderedMatches(t, trie.LikePhrase("city", -1), "city of london", "new york city")
+	verifyMatches(t, trie.LikePhrase("kansas", -1))
+
+	trie.Remove("new york city")
+	trie.Remove("city of london")
+
+	if len(trie.Snapshot().tokens) != 0 {
+		t.Errorf("index should be empty once every word is removed; found %v tokens", len(trie.Snapshot().tokens))
+	}
+}
+
+func TestLikePhraseAfterUnmarshal(t *testing.T) {
+
+	trie := NewTrie(WithPhraseIndex())
+	trie.Insert("kansas city")
+
+	if err := trie.UnmarshalText([]byte("new york city\nyork")); err != nil {
+		t.Fatal(err)
+	}
+
+	verifyOrderedMatches(t, trie.LikePhrase("york", -1), "new york city", "york")
+	verifyMatches(t, trie.LikePhrase("kansas", -1))
+}
+
+func TestTokenize(t *testing.T) {
+
+	tokens := tokenize([]rune("a-b  c, a;d"))
+
+	actual := make([]string, len(tokens))
+	for i, token := range tokens {
+		actual[i] = string(token)
+	}
+
+	verifyOrderedMatches(t, actual, "a", "b", "c", "d")
+}
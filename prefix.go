@@ -0,0 +1,65 @@
+package trie
+
+// LongestPrefix returns the longest word in the Trie that the input starts with, and whether there was one
+func (t *Trie) LongestPrefix(input string) (string, bool) {
+	return t.Snapshot().LongestPrefix(input)
+}
+
+// LongestPrefix returns the longest word in the Snapshot that the input starts with, as Trie.LongestPrefix does
+func (s *Snapshot) LongestPrefix(input string) (string, bool) {
+
+	if len(input) == 0 {
+		return "", false
+	}
+
+	words := prefixes(s.children, s.split(input))
+	if len(words) == 0 {
+		return "", false
+	}
+
+	return s.displayWords(words[len(words)-1:], 1)[0], true
+}
+
+// Prefixes returns every word in the Trie that the input starts with, from shortest to longest
+func (t *Trie) Prefixes(input string) []string {
+	return t.Snapshot().Prefixes(input)
+}
+
+// Prefixes returns every word in the Snapshot that the input starts with, as Trie.Prefixes does
+func (s *Snapshot) Prefixes(input string) []string {
+
+	if len(input) == 0 {
+		return make([]string, 0)
+	}
+
+	return s.displayWords(prefixes(s.children, s.split(input)), -1)
+}
+
+// prefixes follows the input down the tree, collecting every word it passes
+func prefixes(rootChildren []*node, input []rune) []string {
+
+	words := make([]string, 0)
+
+	nodes, consumed := rootChildren, 0
+	for consumed < len(input) {
+
+		_, n := search(nodes, input[consumed])
+		if n == nil {
+			break
+		}
+
+		// the input must cover the whole edge to reach the node
+		if commonPrefix(n.value, input[consumed:]) < len(n.value) {
+			break
+		}
+
+		consumed += len(n.value)
+		if n.endOfWord {
+			words = append(words, string(input[:consumed]))
+		}
+
+		nodes = n.children
+	}
+
+	return words
+}
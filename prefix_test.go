@@ -0,0 +1,50 @@
+package trie
+
+import "testing"
+
+func TestLongestPrefix(t *testing.T) {
+
+	trie := NewTrie()
+	for _, w := range wordsLike {
+		trie.Insert(w)
+	}
+	trie.Insert("ab")
+	trie.Insert("abdom")
+
+	tests := []struct {
+		input string
+		word  string
+		found bool
+	}{
+		{"abdominalpain", "abdominal", true},
+		{"ABDOMINALPAIN", "abdominal", true},
+		{"abdomen", "abdomen", true},
+		{"abdomination", "abdom", true},
+		{"abdo", "ab", true},
+		{"abxyz", "ab", true},
+		{"a", "", false},
+		{"zebra", "", false},
+		{"", "", false},
+	}
+
+	for _, test := range tests {
+		if word, found := trie.LongestPrefix(test.input); word != test.word || found != test.found {
+			t.Errorf("longest prefix of %v should be %v; found %v", test.input, test.word, word)
+		}
+	}
+}
+
+func TestPrefixes(t *testing.T) {
+
+	trie := NewTrie()
+	for _, w := range wordsLike {
+		trie.Insert(w)
+	}
+	trie.Insert("ab")
+	trie.Insert("abdom")
+
+	verifyOrderedMatches(t, trie.Prefixes("abdominalpain"), "ab", "abdom", "abdominal")
+	verifyOrderedMatches(t, trie.Prefixes("aaronites"), "aaron", "aaronite")
+	verifyMatches(t, trie.Prefixes("aar"))
+	verifyMatches(t, trie.Prefixes(""))
+}
@@ -0,0 +1,130 @@
+package trie
+
+// RangeOption changes how Range treats its bounds
+type RangeOption func(*rangeBounds)
+
+// ExclusiveFrom leaves the lower bound of a Range out of the results
+func ExclusiveFrom() RangeOption {
+	return func(b *rangeBounds) { b.fromExclusive = true }
+}
+
+// ExclusiveTo leaves the upper bound of a Range out of the results
+func ExclusiveTo() RangeOption {
+	return func(b *rangeBounds) { b.toExclusive = true }
+}
+
+// Reverse returns the words of a Range in reverse alphabetical order, starting from the upper bound
+func Reverse() RangeOption {
+	return func(b *rangeBounds) { b.reverse = true }
+}
+
+// Range will find the words between from and to in alphabetical order, up to the supplied limit. Both bounds are
+// included unless ExclusiveFrom or ExclusiveTo are supplied. An empty from or to leaves that end of the range
+// unbounded. When the limit cuts the range short, the words nearest the start of the range are returned.
+func (t *Trie) Range(from string, to string, limit int, opts ...RangeOption) []string {
+	return t.Snapshot().Range(from, to, limit, opts...)
+}
+
+// Range finds the words in the Snapshot between from and to, as Trie.Range does
+func (s *Snapshot) Range(from string, to string, limit int, opts ...RangeOption) []string {
+
+	b := &rangeBounds{from: s.split(from), to: s.split(to)}
+	for _, opt := range opts {
+		opt(b)
+	}
+
+	words := make([]string, 0)
+	if limit == 0 {
+		return words
+	}
+
+	b.walk(s.children, nil, func(word []rune, _ *node) bool {
+		words = append(words, string(word))
+		return limit < 0 || len(words) < limit
+	})
+
+	return s.displayWords(words, limit)
+}
+
+// rangeBounds holds the bounds of a Range, where an empty bound is unbounded
+type rangeBounds struct {
+	from          []rune
+	to            []rune
+	fromExclusive bool
+	toExclusive   bool
+	reverse       bool
+}
+
+// walk visits the words in the bounds below the nodes, skipping any node whose
+// words all fall outside them. The walk stops as soon as fn returns false.
+func (b *rangeBounds) walk(nodes []*node, word []rune, fn func([]rune, *node) bool) bool {
+
+	for i := range nodes {
+
+		n := nodes[i]
+		if b.reverse {
+			n = nodes[len(nodes)-1-i]
+		}
+
+		current := append(word, n.value...)
+
+		if b.below(current) {
+			if b.reverse {
+				// every sibling left to visit comes before this one
+				return true
+			}
+			continue
+		}
+
+		if b.above(current) {
+			if !b.reverse {
+				// every sibling left to visit comes after this one
+				return true
+			}
+			continue
+		}
+
+		// a word comes before the words below it, so it is visited last in reverse
+		if !b.reverse && n.endOfWord && b.contains(current) && !fn(current, n) {
+			return false
+		}
+
+		if !b.walk(n.children, current, fn) {
+			return false
+		}
+
+		if b.reverse && n.endOfWord && b.contains(current) && !fn(current, n) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// below reports whether every word starting with the prefix comes before the lower bound
+func (b *rangeBounds) below(prefix []rune) bool {
+	return len(b.from) > 0 && string(prefix) < string(b.from) && commonPrefix(prefix, b.from) < len(prefix)
+}
+
+// above reports whether every word starting with the prefix comes after the upper bound
+func (b *rangeBounds) above(prefix []rune) bool {
+	if len(b.to) == 0 {
+		return false
+	}
+
+	p, to := string(prefix), string(b.to)
+
+	return p > to || (p == to && b.toExclusive)
+}
+
+// contains reports whether the word falls within the bounds
+func (b *rangeBounds) contains(word []rune) bool {
+
+	w, from, to := string(word), string(b.from), string(b.to)
+
+	if len(from) > 0 && (w < from || (w == from && b.fromExclusive)) {
+		return false
+	}
+
+	return len(to) == 0 || w < to || (w == to && !b.toExclusive)
+}
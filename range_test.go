@@ -0,0 +1,88 @@
+package trie
+
+import "testing"
+
+func TestRangeInclusive(t *testing.T) {
+
+	trie := NewTrie()
+	for _, w := range wordsLike {
+		trie.Insert(w)
+	}
+
+	verifyOrderedMatches(t, trie.Range("abandonee", "abator", -1),
+		"abandonee", "abandonware", "abatable", "abator")
+	verifyOrderedMatches(t, trie.Range("ABD", "abdominal", -1),
+		"abdicator", "abdomen", "abdominal")
+}
+
+func TestRangeExclusive(t *testing.T) {
+
+	trie := NewTrie()
+	for _, w := range wordsLike {
+		trie.Insert(w)
+	}
+
+	verifyOrderedMatches(t, trie.Range("abandonee", "abator", -1, ExclusiveFrom()),
+		"abandonware", "abatable", "abator")
+	verifyOrderedMatches(t, trie.Range("abandonee", "abator", -1, ExclusiveTo()),
+		"abandonee", "abandonware", "abatable")
+	verifyOrderedMatches(t, trie.Range("abandonee", "abator", -1, ExclusiveFrom(), ExclusiveTo()),
+		"abandonware", "abatable")
+}
+
+func TestRangeWithPrefixWords(t *testing.T) {
+
+	trie := NewTrie()
+	trie.Insert("a")
+	trie.Insert("ab")
+	trie.Insert("abc")
+	trie.Insert("abd")
+	trie.Insert("b")
+
+	verifyOrderedMatches(t, trie.Range("ab", "abc", -1), "ab", "abc")
+	verifyOrderedMatches(t, trie.Range("aa", "ab", -1, ExclusiveTo()))
+	verifyOrderedMatches(t, trie.Range("ab", "b", -1, ExclusiveFrom(), ExclusiveTo()), "abc", "abd")
+	verifyOrderedMatches(t, trie.Range("abc", "abcz", -1), "abc")
+}
+
+func TestRangeUnbounded(t *testing.T) {
+
+	trie := NewTrie()
+	for _, w := range wordsAlphabet {
+		trie.Insert(w)
+	}
+
+	verifyOrderedMatches(t, trie.Range("", "charlie", -1), "alpha", "bravo", "charlie")
+	verifyOrderedMatches(t, trie.Range("x", "", -1), "xray", "yankee", "zulu")
+	verifyOrderedMatches(t, trie.Range("", "", -1), wordsAlphabet...)
+}
+
+func TestRangeLimit(t *testing.T) {
+
+	trie := NewTrie()
+	for _, w := range wordsAlphabet {
+		trie.Insert(w)
+	}
+
+	verifyOrderedMatches(t, trie.Range("b", "y", 2), "bravo", "charlie")
+	verifyOrderedMatches(t, trie.Range("b", "y", 2, Reverse()), "xray", "whiskey")
+	verifyMatches(t, trie.Range("b", "y", 0))
+}
+
+func TestRangeReverse(t *testing.T) {
+
+	trie := NewTrie()
+	for _, w := range wordsLike {
+		trie.Insert(w)
+	}
+	trie.Insert("abd")
+
+	verifyOrderedMatches(t, trie.Range("abc", "abdominal", -1, Reverse()),
+		"abdominal", "abdomen", "abdicator", "abd")
+	verifyOrderedMatches(t, trie.Range("abd", "abdominal", -1, Reverse(), ExclusiveFrom(), ExclusiveTo()),
+		"abdomen", "abdicator")
+}
+
+func TestRangeEmptyTrie(t *testing.T) {
+	verifyMatches(t, NewTrie().Range("a", "z", -1))
+}
@@ -0,0 +1,90 @@
+package trie
+
+import (
+	"container/heap"
+	"sort"
+)
+
+// LikeTopK returns up to k words that start with the prefix, ordered by the
+// score they were inserted with, highest first. Words with the same score are
+// ordered alphabetically. A negative k returns every match.
+func (t *Trie) LikeTopK(prefix string, k int) []string {
+	return t.Snapshot().LikeTopK(prefix, k)
+}
+
+// LikeTopK finds the highest scoring words in the Snapshot that start with the prefix, as Trie.LikeTopK does
+func (s *Snapshot) LikeTopK(prefix string, k int) []string {
+
+	if len(prefix) == 0 {
+		return make([]string, 0)
+	}
+
+	return s.displayWords(likeTopK(s.children, s.split(prefix), k), k)
+}
+
+// scoredWord is a candidate completion and the score of its terminal node
+type scoredWord struct {
+	word  string
+	score float64
+}
+
+// before reports whether a ranks ahead of b
+func (a scoredWord) before(b scoredWord) bool {
+	if a.score != b.score {
+		return a.score > b.score
+	}
+
+	return a.word < b.word
+}
+
+// rankHeap is a min-heap that keeps the worst ranked word on top, so it can be
+// evicted when a better candidate is found
+type rankHeap []scoredWord
+
+func (h rankHeap) Len() int            { return len(h) }
+func (h rankHeap) Less(i, j int) bool  { return h[j].before(h[i]) }
+func (h rankHeap) Swap(i, j int)       { h[i], h[j] = h[j], h[i] }
+func (h *rankHeap) Push(x interface{}) { *h = append(*h, x.(scoredWord)) }
+func (h *rankHeap) Pop() interface{} {
+	old := *h
+	x := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return x
+}
+
+func likeTopK(rootChildren []*node, prefix []rune, k int) []string {
+
+	words := make([]string, 0)
+
+	endOfPrefix, word := complete(rootChildren, prefix)
+	if endOfPrefix == nil || k == 0 {
+		return words
+	}
+
+	h := make(rankHeap, 0)
+	offer := func(word []rune, n *node) bool {
+		candidate := scoredWord{word: string(word), score: n.score()}
+
+		if k < 0 || h.Len() < k {
+			heap.Push(&h, candidate)
+		} else if candidate.before(h[0]) {
+			h[0] = candidate
+			heap.Fix(&h, 0)
+		}
+
+		return true
+	}
+
+	if endOfPrefix.endOfWord {
+		offer(word, endOfPrefix)
+	}
+
+	walk(endOfPrefix, word, offer)
+
+	sort.Slice(h, func(i, j int) bool { return h[i].before(h[j]) })
+	for _, w := range h {
+		words = append(words, w.word)
+	}
+
+	return words
+}
@@ -0,0 +1,85 @@
+package trie
+
+import "testing"
+
+func TestLikeTopKEmptyPrefix(t *testing.T) {
+
+	trie := NewTrie()
+	trie.InsertWeighted("abacus", 10)
+
+	verifyMatches(t, trie.LikeTopK("", 5))
+}
+
+func TestLikeTopKNoMatches(t *testing.T) {
+
+	trie := NewTrie()
+	trie.InsertWeighted("abacus", 10)
+
+	verifyMatches(t, trie.LikeTopK("b", 5))
+}
+
+func TestLikeTopKOrdersByScore(t *testing.T) {
+
+	trie := NewTrie()
+	for _, w := range wordsLike {
+		trie.Insert(w)
+	}
+	trie.InsertWeighted("abacus", 10)
+	trie.InsertWeighted("abdomen", 5)
+	trie.InsertWeighted("abby", 7)
+
+	verifyOrderedMatches(t, trie.LikeTopK("a", 3), "abacus", "abby", "abdomen")
+}
+
+func TestLikeTopKBreaksTiesAlphabetically(t *testing.T) {
+
+	trie := NewTrie()
+	trie.InsertWeighted("abductor", 2)
+	trie.InsertWeighted("abduce", 2)
+	trie.InsertWeighted("abductee", 2)
+	trie.InsertWeighted("abdomen", 1)
+
+	verifyOrderedMatches(t, trie.LikeTopK("abd", -1), "abduce", "abductee", "abductor", "abdomen")
+}
+
+func TestLikeTopKIncludesPrefixWord(t *testing.T) {
+
+	trie := NewTrie()
+	trie.InsertWeighted("foo", 3)
+	trie.InsertWeighted("foobar", 1)
+
+	verifyOrderedMatches(t, trie.LikeTopK("foo", 1), "foo")
+}
+
+func TestLikeTopKZeroCount(t *testing.T) {
+
+	trie := NewTrie()
+	trie.InsertWeighted("abacus", 10)
+
+	verifyMatches(t, trie.LikeTopK("a", 0))
+}
+
+func TestInsertWeightedReplacesScore(t *testing.T) {
+
+	trie := NewTrie()
+	trie.InsertWeighted("alpha", 1)
+	trie.InsertWeighted("beta", 2)
+	trie.InsertWeighted("alpha", 3)
+
+	if trie.Count() != 2 {
+		t.Error("trie should have two words")
+	}
+
+	verifyOrderedMatches(t, trie.LikeTopK("a", 1), "alpha")
+}
+
+func TestRemoveResetsScore(t *testing.T) {
+
+	trie := NewTrie()
+	trie.InsertWeighted("ab", 5)
+	trie.InsertWeighted("abc", 1)
+	trie.Remove("ab")
+	trie.Insert("ab")
+
+	verifyOrderedMatches(t, trie.LikeTopK("a", -1), "abc", "ab")
+}
@@ -0,0 +1,189 @@
+package trie
+
+import "regexp/syntax"
+
+// MatchRegexp will find the words that match a regular expression, up to the supplied limit. The expression uses
+// the same syntax and matching rules as the regexp package, so it matches anywhere in a word unless it is anchored
+// with ^ and $. The expression is matched against words as they are stored, so with the default Normalizer it
+// should be written in lowercase.
+// Matches are returned in alphabetical order.
+func (t *Trie) MatchRegexp(pattern string, limit int) ([]string, error) {
+	return t.Snapshot().MatchRegexp(pattern, limit)
+}
+
+// MatchRegexp finds the words in the Snapshot that match a regular expression, as Trie.MatchRegexp does
+func (s *Snapshot) MatchRegexp(pattern string, limit int) ([]string, error) {
+
+	re, err := syntax.Parse(pattern, syntax.Perl)
+	if err != nil {
+		return nil, err
+	}
+
+	prog, err := syntax.Compile(re.Simplify())
+	if err != nil {
+		return nil, err
+	}
+
+	return s.displayWords(matchRegexp(s.children, prog, limit), limit), nil
+}
+
+// automaton runs a compiled regular expression one rune at a time, so it can
+// follow the paths through the tree instead of being run against each word
+type automaton struct {
+	prog     *syntax.Prog
+	anchored bool
+}
+
+func matchRegexp(rootChildren []*node, prog *syntax.Prog, limit int) []string {
+
+	words := make([]string, 0)
+
+	startCond := prog.StartCond()
+	if limit == 0 || startCond == ^syntax.EmptyOp(0) {
+		// the expression cannot match anything
+		return words
+	}
+
+	a := &automaton{
+		prog:     prog,
+		anchored: startCond&syntax.EmptyBeginText != 0,
+	}
+
+	a.findMatches(rootChildren, []uint32{uint32(prog.Start)}, -1, nil, &words, limit)
+
+	return words
+}
+
+// findMatches steps the automaton into each of the nodes. threads holds the
+// instructions waiting for the next rune, and prev is the last rune consumed.
+func (a *automaton) findMatches(nodes []*node, threads []uint32, prev rune, word []rune, words *[]string, limit int) {
+
+	for _, n := range nodes {
+
+		if limit >= 0 && len(*words) >= limit {
+			return
+		}
+
+		current := append(word, n.value...)
+
+		next, last, matched := threads, prev, false
+		for i := 0; !matched && len(next) > 0 && i < len(n.value); i++ {
+			next, matched = a.step(next, last, n.value[i])
+			last = n.value[i]
+		}
+
+		if matched {
+			// the expression matched part way along, so every word below matches too
+			collect(n, current, words, limit)
+			continue
+		}
+
+		if len(next) == 0 {
+			// no thread survived, so nothing below this node can match
+			continue
+		}
+
+		if n.endOfWord {
+			if _, matched := a.closure(next, syntax.EmptyOpContext(last, -1)); matched {
+				*words = append(*words, string(current))
+			}
+		}
+
+		a.findMatches(n.children, next, last, current, words, limit)
+	}
+}
+
+// step follows the threads past the empty width instructions that hold between
+// prev and r, and then consumes r. It reports whether the expression already
+// matched without consuming r.
+func (a *automaton) step(threads []uint32, prev rune, r rune) ([]uint32, bool) {
+
+	runes, matched := a.closure(threads, syntax.EmptyOpContext(prev, r))
+	if matched {
+		return nil, true
+	}
+
+	seen := make(map[uint32]bool)
+	next := make([]uint32, 0, len(runes)+1)
+
+	for _, pc := range runes {
+		i := &a.prog.Inst[pc]
+		if consumes(i, r) && !seen[i.Out] {
+			seen[i.Out] = true
+			next = append(next, i.Out)
+		}
+	}
+
+	// an unanchored expression may also start matching after r
+	if !a.anchored && !seen[uint32(a.prog.Start)] {
+		next = append(next, uint32(a.prog.Start))
+	}
+
+	return next, false
+}
+
+// closure returns every rune instruction reachable from the threads when the
+// empty width conditions in ctx hold, and whether a match instruction was reached
+func (a *automaton) closure(threads []uint32, ctx syntax.EmptyOp) ([]uint32, bool) {
+
+	var matched bool
+
+	runes := make([]uint32, 0, len(threads))
+	seen := make([]bool, len(a.prog.Inst))
+	stack := append([]uint32{}, threads...)
+
+	for len(stack) > 0 {
+		pc := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+
+		if seen[pc] {
+			continue
+		}
+		seen[pc] = true
+
+		i := &a.prog.Inst[pc]
+		switch i.Op {
+		case syntax.InstAlt, syntax.InstAltMatch:
+			stack = append(stack, i.Arg, i.Out)
+		case syntax.InstCapture, syntax.InstNop:
+			stack = append(stack, i.Out)
+		case syntax.InstEmptyWidth:
+			if syntax.EmptyOp(i.Arg)&^ctx == 0 {
+				stack = append(stack, i.Out)
+			}
+		case syntax.InstMatch:
+			matched = true
+		case syntax.InstRune, syntax.InstRune1, syntax.InstRuneAny, syntax.InstRuneAnyNotNL:
+			runes = append(runes, pc)
+		}
+	}
+
+	return runes, matched
+}
+
+// consumes reports whether the rune instruction i accepts r
+func consumes(i *syntax.Inst, r rune) bool {
+	switch i.Op {
+	case syntax.InstRuneAny:
+		return true
+	case syntax.InstRuneAnyNotNL:
+		return r != '\n'
+	default:
+		return i.MatchRune(r)
+	}
+}
+
+// collect adds n and the words below it, up to the supplied limit
+func collect(n *node, word []rune, words *[]string, limit int) {
+
+	add := func(w []rune, _ *node) bool {
+		*words = append(*words, string(w))
+		return limit < 0 || len(*words) < limit
+	}
+
+	if n.endOfWord && !add(word, n) {
+		return
+	}
+
+	walk(n, word, add)
+}
@@ -0,0 +1,85 @@
+package trie
+
+import (
+	"regexp"
+	"testing"
+)
+
+func TestMatchRegexpInvalidPattern(t *testing.T) {
+
+	trie := NewTrie()
+
+	if _, err := trie.MatchRegexp("ab(", -1); err == nil {
+		t.Error("an invalid pattern should return an error")
+	}
+}
+
+func TestMatchRegexpAnchored(t *testing.T) {
+
+	trie := NewTrie()
+	for _, w := range wordsLike {
+		trie.Insert(w)
+	}
+
+	words, err := trie.MatchRegexp(`^ab(d|c)o.*n$`, -1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	verifyOrderedMatches(t, words, "abdomen")
+}
+
+func TestMatchRegexpLimit(t *testing.T) {
+
+	trie := NewTrie()
+	for _, w := range wordsLike {
+		trie.Insert(w)
+	}
+
+	words, _ := trie.MatchRegexp(`^abd`, 2)
+	verifyOrderedMatches(t, words, "abdicator", "abdomen")
+
+	words, _ = trie.MatchRegexp(`^abd`, 0)
+	verifyMatches(t, words)
+}
+
+func TestMatchRegexpNeverMatches(t *testing.T) {
+
+	trie := NewTrie()
+	for _, w := range wordsLike {
+		trie.Insert(w)
+	}
+
+	words, _ := trie.MatchRegexp(`a^b`, -1)
+	verifyMatches(t, words)
+}
+
+func TestMatchRegexpAgreesWithRegexpPackage(t *testing.T) {
+
+	trie := NewTrie()
+	for _, w := range wordsLike {
+		trie.Insert(w)
+	}
+
+	patterns := []string{
+		`tor`, `^aa`, `ee$`, `^a.{4}$`, `b+[aeiou]`, `(ab|ba)c`, `\bab`, `n\b`, `^$`, `x*`, `on(e|ite)?$`,
+	}
+
+	for _, p := range patterns {
+
+		words, err := trie.MatchRegexp(p, -1)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		expected := make([]string, 0)
+		re := regexp.MustCompile(p)
+		for _, w := range trie.Like("a", -1) {
+			if re.MatchString(w) {
+				expected = append(expected, w)
+			}
+		}
+
+		verifyOrderedMatches(t, words, expected...)
+	}
+}
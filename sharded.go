@@ -0,0 +1,198 @@
+package trie
+
+import (
+	"iter"
+	"runtime"
+	"slices"
+)
+
+// shardRunes is how many leading runes of a word decide the shard it is stored in
+const shardRunes = 2
+
+// ShardedTrie spreads its words across several Tries by their first runes, so
+// that writers changing different shards do not wait for each other. Words are
+// returned in alphabetical order across every shard, as a single Trie would
+// return them.
+type ShardedTrie struct {
+	shards []*Trie
+}
+
+// NewShardedTrie initializes a ShardedTrie with the supplied number of shards, each created with the supplied
+// options. When shards is less than one there is a shard for each processor Go can use.
+func NewShardedTrie(shards int, opts ...Option) *ShardedTrie {
+
+	if shards < 1 {
+		shards = runtime.GOMAXPROCS(0)
+	}
+
+	st := &ShardedTrie{shards: make([]*Trie, shards)}
+	for i := range st.shards {
+		st.shards[i] = NewTrie(opts...)
+	}
+
+	return st
+}
+
+// Count returns the number of unique words stored in every shard. Words may be inserted or removed while the
+// shards are being counted.
+func (st *ShardedTrie) Count() int {
+
+	var count int
+	for _, shard := range st.shards {
+		count += shard.Count()
+	}
+
+	return count
+}
+
+// Insert will insert a new word into the shard it belongs to, as Trie.Insert does
+func (st *ShardedTrie) Insert(word string) {
+
+	if len(word) == 0 {
+		return
+	}
+
+	runes := st.split(word)
+	st.shard(runes).update(func(s *Snapshot) {
+		s.add(runes, word)
+	})
+}
+
+// Contains will check the shard the word belongs to, to see if the word is currently stored
+func (st *ShardedTrie) Contains(word string) bool {
+
+	if len(word) == 0 {
+		return false
+	}
+
+	runes := st.split(word)
+	found, _ := contains(st.shard(runes).Snapshot().children, runes)
+
+	return found
+}
+
+// Remove will remove a word from the shard it belongs to if it exists, and do nothing if it does not exist
+func (st *ShardedTrie) Remove(word string) {
+
+	if len(word) == 0 {
+		return
+	}
+
+	runes := st.split(word)
+	st.shard(runes).update(func(s *Snapshot) {
+		s.delete(runes)
+	})
+}
+
+// Like will find the words that start with the prefix in alphabetical order, up to the supplied count. A prefix
+// shorter than the runes that pick a shard is searched for in every shard.
+func (st *ShardedTrie) Like(prefix string, count int) []string {
+
+	if len(prefix) == 0 {
+		return make([]string, 0)
+	}
+
+	runes := st.split(prefix)
+	if len(runes) >= shardRunes {
+		s := st.shard(runes).Snapshot()
+		return s.displayWords(like(s.children, runes, count), count)
+	}
+
+	snapshots := st.snapshots()
+	matches := make([]iter.Seq[string], len(snapshots))
+	for i, s := range snapshots {
+		matches[i] = slices.Values(like(s.children, runes, count))
+	}
+
+	words := make([]string, 0)
+	mergeShards(matches, func(word string, shard int) bool {
+		for _, w := range snapshots[shard].displayWords([]string{word}, -1) {
+			if count >= 0 && len(words) >= count {
+				return false
+			}
+			words = append(words, w)
+		}
+		return true
+	})
+
+	return words
+}
+
+// All returns an iterator over every word in every shard in alphabetical order. Each shard is read as it was
+// when the iteration started.
+func (st *ShardedTrie) All() iter.Seq[string] {
+
+	return func(yield func(string) bool) {
+
+		snapshots := st.snapshots()
+		words := make([]iter.Seq[string], len(snapshots))
+		for i, s := range snapshots {
+			words[i] = s.All()
+		}
+
+		mergeShards(words, func(word string, _ int) bool {
+			return yield(word)
+		})
+	}
+}
+
+// split normalizes the word as every shard does
+func (st *ShardedTrie) split(word string) []rune {
+	return st.shards[0].split(word)
+}
+
+// shard returns the Trie that the normalized word belongs in
+func (st *ShardedTrie) shard(word []rune) *Trie {
+
+	var h uint32
+	for i := 0; i < len(word) && i < shardRunes; i++ {
+		h = h*31 + uint32(word[i])
+	}
+
+	return st.shards[h%uint32(len(st.shards))]
+}
+
+// snapshots returns the latest Snapshot of each shard
+func (st *ShardedTrie) snapshots() []*Snapshot {
+
+	snapshots := make([]*Snapshot, len(st.shards))
+	for i, shard := range st.shards {
+		snapshots[i] = shard.Snapshot()
+	}
+
+	return snapshots
+}
+
+// mergeShards calls fn with the words from each shard in alphabetical order,
+// along with the index of the shard the word came from, until fn returns false.
+// The words from each shard must already be in order, and no word may be in
+// more than one shard.
+func mergeShards(shards []iter.Seq[string], fn func(string, int) bool) {
+
+	next := make([]func() (string, bool), len(shards))
+	heads := make([]string, len(shards))
+	ok := make([]bool, len(shards))
+
+	for i, words := range shards {
+		var stop func()
+		next[i], stop = iter.Pull(words)
+		defer stop()
+
+		heads[i], ok[i] = next[i]()
+	}
+
+	for {
+		first := -1
+		for i := range heads {
+			if ok[i] && (first < 0 || heads[i] < heads[first]) {
+				first = i
+			}
+		}
+
+		if first < 0 || !fn(heads[first], first) {
+			return
+		}
+
+		heads[first], ok[first] = next[first]()
+	}
+}
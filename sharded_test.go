@@ -0,0 +1,106 @@
+package trie
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestShardedTrie(t *testing.T) {
+
+	trie := NewShardedTrie(4)
+	for _, w := range wordsLike {
+		trie.Insert(w)
+	}
+
+	if trie.Count() != len(wordsLike) {
+		t.Errorf("trie should hold %v words; found %v", len(wordsLike), trie.Count())
+	}
+
+	if !trie.Contains("Abdomen") || trie.Contains("abdo") || trie.Contains("") {
+		t.Error("trie should contain abdomen and nothing else like it")
+	}
+
+	trie.Remove("abdomen")
+	trie.Remove("")
+
+	if trie.Contains("abdomen") || trie.Count() != len(wordsLike)-1 {
+		t.Error("abdomen should have been removed")
+	}
+
+	verifyOrderedMatches(t, trie.Like("abdo", -1), "abdominal", "abdominocentesis")
+}
+
+func TestShardedTrieLikeMergesShards(t *testing.T) {
+
+	trie := NewShardedTrie(3)
+	single := NewTrie()
+
+	for _, w := range wordsLike {
+		trie.Insert(w)
+		single.Insert(w)
+	}
+
+	verifyOrderedMatches(t, trie.Like("a", -1), single.Like("a", -1)...)
+	verifyOrderedMatches(t, trie.Like("a", 5), single.Like("a", 5)...)
+	verifyMatches(t, trie.Like("a", 0))
+	verifyMatches(t, trie.Like("", -1))
+	verifyMatches(t, trie.Like("z", -1))
+
+	words := make([]string, 0)
+	for w := range trie.All() {
+		words = append(words, w)
+	}
+
+	verifyOrderedMatches(t, words, wordsLike...)
+}
+
+func TestShardedTrieWithOptions(t *testing.T) {
+
+	trie := NewShardedTrie(2, WithDisplayForms(AllForms))
+	trie.Insert("Aachen")
+	trie.Insert("AACHEN")
+	trie.Insert("Aaron")
+	trie.Insert("a")
+
+	verifyOrderedMatches(t, trie.Like("a", -1), "a", "Aachen", "AACHEN", "Aaron")
+	verifyOrderedMatches(t, trie.Like("a", 2), "a", "Aachen")
+	verifyOrderedMatches(t, trie.Like("aa", -1), "Aachen", "AACHEN", "Aaron")
+}
+
+func TestShardedTrieDefaultShards(t *testing.T) {
+
+	trie := NewShardedTrie(0)
+	if len(trie.shards) < 1 {
+		t.Error("trie should have at least one shard")
+	}
+}
+
+func TestShardedTrieConcurrentInserts(t *testing.T) {
+
+	var wg sync.WaitGroup
+
+	trie := NewShardedTrie(4)
+	words := []string{"aabc", "abbb", "abca", "aabb", "aaca", "accb", "acba", "bbac", "babc", "bbbb"}
+
+	for i := 0; i < 10000; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for _, w := range words {
+				trie.Insert(w)
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	if trie.Count() != len(words) {
+		t.Errorf("Trie should contain %v words but found %v", len(words), trie.Count())
+	}
+
+	for _, w := range words {
+		if !trie.Contains(w) {
+			t.Errorf("Trie should contain %v and it does not", w)
+		}
+	}
+}
@@ -0,0 +1,125 @@
+package trie
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestSnapshotDoesNotSeeLaterChanges(t *testing.T) {
+
+	trie := NewTrie()
+	trie.Insert("abdomen")
+	trie.Insert("abdominal")
+
+	snapshot := trie.Snapshot()
+
+	trie.Insert("abduct")
+	trie.Remove("abdomen")
+
+	verifyOrderedMatches(t, snapshot.Like("abd", -1), "abdomen", "abdominal")
+	verifyOrderedMatches(t, trie.Like("abd", -1), "abdominal", "abduct")
+
+	if snapshot.Count() != 2 || !snapshot.Contains("abdomen") || snapshot.Contains("abduct") {
+		t.Error("snapshot should hold the words from when it was taken")
+	}
+}
+
+func TestSnapshotKeepsIndexesAndForms(t *testing.T) {
+
+	trie := NewTrie(WithSuffixIndex(), WithSubstringIndex(), WithPhraseIndex(), WithDisplayForms(AllForms))
+	trie.Insert("New York")
+
+	snapshot := trie.Snapshot()
+
+	trie.Insert("new york")
+	trie.Remove("new york")
+
+	verifyOrderedMatches(t, snapshot.EndsWith("york", -1), "New York")
+	verifyOrderedMatches(t, snapshot.Substring("w y", -1), "New York")
+	verifyOrderedMatches(t, snapshot.LikePhrase("york", -1), "New York")
+	verifyMatches(t, trie.LikePhrase("york", -1))
+}
+
+func TestSnapshotIterator(t *testing.T) {
+
+	trie := NewTrie()
+	trie.Insert("a")
+	trie.Insert("b")
+
+	snapshot := trie.Snapshot()
+	it := snapshot.Iterator()
+	it.Next()
+
+	trie.Insert("aa")
+	trie.Remove("b")
+
+	words := []string{it.Word()}
+	for it.Next() {
+		words = append(words, it.Word())
+	}
+
+	verifyOrderedMatches(t, words, "a", "b")
+
+	words = words[:0]
+	for w := range snapshot.WithPrefix("A") {
+		words = append(words, w)
+	}
+
+	verifyOrderedMatches(t, words, "a")
+}
+
+func TestSnapshotOfZeroTrie(t *testing.T) {
+
+	var trie Trie
+
+	if trie.Snapshot().Count() != 0 || trie.Contains("a") {
+		t.Error("zero trie should be empty")
+	}
+
+	trie.Insert("a")
+	verifyOrderedMatches(t, trie.Like("a", -1), "a")
+}
+
+func TestReadsDuringWrites(t *testing.T) {
+
+	trie := NewTrie()
+	for _, w := range wordsAlphabet {
+		trie.Insert(w)
+	}
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+
+	go func() {
+		defer wg.Done()
+		for _, w := range wordsLike {
+			trie.Insert(w)
+		}
+		for _, w := range wordsLike {
+			trie.Remove(w)
+		}
+	}()
+
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 100; i++ {
+			snapshot := trie.Snapshot()
+
+			var words int
+			for range snapshot.All() {
+				words++
+			}
+
+			if words != snapshot.Count() {
+				t.Errorf("snapshot should hold %v words; found %v", snapshot.Count(), words)
+				return
+			}
+		}
+	}()
+
+	wg.Wait()
+
+	if trie.Count() != len(wordsAlphabet) {
+		t.Errorf("trie should hold %v words; found %v", len(wordsAlphabet), trie.Count())
+	}
+}
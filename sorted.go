@@ -0,0 +1,238 @@
+package trie
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+)
+
+// ErrNotSorted is returned when words that must be sorted are not
+var ErrNotSorted = errors.New("trie: words are not sorted")
+
+// BuildFromSorted creates a Trie with the supplied options from the words on each line read from r. The words
+// must be sorted once they are normalized, which for the default Normalizer means sorted in lowercase, and may be
+// repeated. Blank lines are ignored. Building from sorted words is much faster than inserting them one at a time.
+func BuildFromSorted(r io.Reader, opts ...Option) (*Trie, error) {
+
+	t := NewTrie(opts...)
+	b := newBuilder(t)
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		if err := b.add(scanner.Text()); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	t.current.Store(b.snapshot)
+
+	return t, nil
+}
+
+// InsertSorted will insert the words, which must be sorted as BuildFromSorted requires, into the Trie as a
+// single change. The Trie is left unchanged if the words are not sorted. The words are merged into the tree in
+// one pass, so each node they pass through is copied once rather than once for every word.
+func (t *Trie) InsertSorted(words []string) error {
+
+	runes := make([][]rune, 0, len(words))
+	texts := make([]string, 0, len(words))
+
+	for _, w := range words {
+
+		word := t.split(w)
+		if len(word) == 0 {
+			continue
+		}
+
+		if last := len(runes) - 1; last >= 0 && slices.Compare(word, runes[last]) < 0 {
+			return fmt.Errorf("%w: %q comes before %q", ErrNotSorted, w, texts[last])
+		}
+
+		runes, texts = append(runes, word), append(texts, w)
+	}
+
+	if len(runes) == 0 {
+		return nil
+	}
+
+	t.update(func(s *Snapshot) {
+
+		if s.count == 0 {
+			b := newBuilder(t)
+			for _, w := range texts {
+				b.add(w)
+			}
+			b.snapshot.version = s.version + 1
+			*s = *b.snapshot
+			return
+		}
+
+		var inserted bool
+		s.children = insertSorted(s.children, runes, 0, 0, func(n *node, i int, added bool) {
+
+			if s.display != 0 {
+				n.addForm(texts[i])
+			}
+
+			if added {
+				inserted = true
+				s.count++
+				s.index(runes[i])
+			}
+		})
+
+		if inserted {
+			s.version++
+		}
+	})
+
+	return nil
+}
+
+// insertSorted returns a copy of the nodes with the sorted words merged in,
+// where every word has already passed through its first depth runes. Each node
+// along the way is copied once however many of the words pass through it. end
+// is called with the node that the word at first+i ends on, and whether the
+// word was not already there.
+func insertSorted(nodes []*node, words [][]rune, first int, depth int, end func(*node, int, bool)) []*node {
+
+	merged := make([]*node, 0, len(nodes)+1)
+
+	for len(words) > 0 {
+
+		// the words are sorted, so the words starting with the same rune are together
+		r := words[0][depth]
+		k := 1
+		for k < len(words) && words[k][depth] == r {
+			k++
+		}
+
+		index, n := search(nodes, r)
+		merged = append(merged, nodes[:index]...)
+
+		var c *node
+		if n == nil {
+			// a new edge as long as the words have in common, with nothing below it yet
+			prefix := words[0][depth : depth+commonPrefix(words[0][depth:], words[k-1][depth:])]
+			c = &node{value: append([]rune{}, prefix...), children: make([]*node, 0)}
+		} else {
+			c = n.clone()
+			index++
+		}
+
+		// the words leave the edge where the first or last of them does
+		common := min(commonPrefix(c.value, words[0][depth:]), commonPrefix(c.value, words[k-1][depth:]))
+		if common < len(c.value) {
+			split(c, common)
+		}
+
+		// the words ending on c come first, and any after the first are repeats
+		j := 0
+		for ; j < k && len(words[j]) == depth+common; j++ {
+			end(c, first+j, j == 0 && !c.endOfWord)
+			c.endOfWord = true
+		}
+
+		if j < k {
+			c.children = insertSorted(c.children, words[j:k], first+j, depth+common, end)
+		}
+
+		merged = append(merged, c)
+		nodes = nodes[index:]
+		words, first = words[k:], first+k
+	}
+
+	return append(merged, nodes...)
+}
+
+// builder makes a new tree from words in sorted order. Every word comes after
+// the one before it, so it only ever adds to the end of the path to the last
+// word, and none of the nodes it makes can be read until it is done.
+type builder struct {
+	snapshot *Snapshot
+
+	// path holds the nodes along the last word, and starts holds where the value
+	// of each of them starts in the word
+	path   []*node
+	starts []int
+	last   []rune
+	text   string
+}
+
+func newBuilder(t *Trie) *builder {
+	return &builder{snapshot: &Snapshot{settings: &t.settings, children: make([]*node, 0)}}
+}
+
+// add adds the word after the words before it, or returns ErrNotSorted if it comes before the last of them
+func (b *builder) add(text string) error {
+
+	if len(text) == 0 {
+		return nil
+	}
+
+	word := b.snapshot.split(text)
+	if len(word) == 0 {
+		return nil
+	}
+
+	if slices.Compare(word, b.last) < 0 {
+		return fmt.Errorf("%w: %q comes before %q", ErrNotSorted, text, b.text)
+	}
+
+	n, inserted := b.append(word)
+
+	if b.snapshot.display != 0 {
+		n.addForm(text)
+	}
+
+	if inserted {
+		b.snapshot.count++
+		b.snapshot.index(word)
+	}
+
+	b.last, b.text = word, text
+
+	return nil
+}
+
+// append adds a word that is not before the last word to the end of the tree,
+// returning the node it ends on and whether it is a new word
+func (b *builder) append(word []rune) (*node, bool) {
+
+	common := commonPrefix(b.last, word)
+	if common == len(word) && common == len(b.last) {
+		// the same word again
+		return b.path[len(b.path)-1], false
+	}
+
+	// the nodes that start after the words part are finished with
+	k := len(b.path)
+	for k > 0 && b.starts[k-1] >= common {
+		k--
+	}
+	b.path, b.starts = b.path[:k], b.starts[:k]
+
+	created := create(word[common:])
+
+	if k == 0 {
+		b.snapshot.children = append(b.snapshot.children, created)
+	} else {
+		p := b.path[k-1]
+		if at := common - b.starts[k-1]; at < len(p.value) {
+			// the words part way along the edge, so the edge needs a node there
+			split(p, at)
+		}
+		p.children = append(p.children, created)
+	}
+
+	b.path = append(b.path, created)
+	b.starts = append(b.starts, common)
+
+	return created, true
+}
@@ -0,0 +1,185 @@
+package trie
+
+import (
+	"errors"
+	"os"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestBuildFromSorted(t *testing.T) {
+
+	f, err := os.Open("test/likeWords")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	built, err := BuildFromSorted(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	inserted := NewTrie()
+	for _, w := range wordsLike {
+		inserted.Insert(w)
+	}
+
+	verifySameTree(t, built.Snapshot().children, inserted.Snapshot().children, "")
+
+	if built.Count() != len(wordsLike) {
+		t.Errorf("trie should hold %v words; found %v", len(wordsLike), built.Count())
+	}
+
+	verifyOrderedMatches(t, built.Like("abdo", -1), "abdomen", "abdominal", "abdominocentesis")
+}
+
+func TestBuildFromSortedSplitsEdges(t *testing.T) {
+
+	built, err := BuildFromSorted(strings.NewReader("ab\nabcd\nabcd\nabcdef\nabx\nb\n\nba"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectations := []nodeExpectation{
+		{value: "ab", children: []string{"cd", "x"}, nextChild: "cd", endOfWord: true},
+		{value: "cd", children: []string{"ef"}, nextChild: "ef", endOfWord: true},
+		{value: "ef", endOfWord: true}}
+
+	children := built.Snapshot().children
+	verifyExpectations(t, children[0], expectations, 0, "ab")
+	verifyExpectations(t, children[1], []nodeExpectation{{value: "b", children: []string{"a"}, endOfWord: true}}, 0, "b")
+
+	if built.Count() != 6 {
+		t.Errorf("trie should hold 6 words; found %v", built.Count())
+	}
+}
+
+func TestBuildFromSortedWithOptions(t *testing.T) {
+
+	built, err := BuildFromSorted(strings.NewReader("Aachen\naachen\nAaron\r\n"), WithDisplayForms(AllForms), WithSuffixIndex())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	verifyOrderedMatches(t, built.Like("aa", -1), "Aachen", "aachen", "Aaron")
+	verifyOrderedMatches(t, built.EndsWith("ron", -1), "Aaron")
+}
+
+func TestBuildFromSortedRejectsUnsortedWords(t *testing.T) {
+
+	_, err := BuildFromSorted(strings.NewReader("alpha\nbravo\nabacus"))
+	if !errors.Is(err, ErrNotSorted) {
+		t.Errorf("unsorted words should return ErrNotSorted; found %v", err)
+	}
+
+	_, err = BuildFromSorted(strings.NewReader("abc\nab"))
+	if !errors.Is(err, ErrNotSorted) {
+		t.Errorf("a word before its own prefix should return ErrNotSorted; found %v", err)
+	}
+}
+
+func TestInsertSorted(t *testing.T) {
+
+	trie := NewTrie(WithPhraseIndex())
+
+	if err := trie.InsertSorted([]string{"kansas city", "new york", "", "york"}); err != nil {
+		t.Fatal(err)
+	}
+
+	verifyOrderedMatches(t, trie.LikePhrase("york", -1), "new york", "york")
+
+	if err := trie.InsertSorted([]string{"boston", "new jersey", "new york"}); err != nil {
+		t.Fatal(err)
+	}
+
+	verifyOrderedMatches(t, trie.LikePhrase("new", -1), "new jersey", "new york")
+
+	if trie.Count() != 5 {
+		t.Errorf("trie should hold 5 words; found %v", trie.Count())
+	}
+
+	if err := trie.InsertSorted([]string{"zurich", "chicago"}); !errors.Is(err, ErrNotSorted) {
+		t.Errorf("unsorted words should return ErrNotSorted; found %v", err)
+	}
+
+	if trie.Contains("zurich") {
+		t.Error("trie should be unchanged when the words are not sorted")
+	}
+}
+
+func TestInsertSortedMergesIntoExistingWords(t *testing.T) {
+
+	existing := []string{"abacus", "abdomen", "foo", "foobar", "zulu"}
+	sorted := []string{"Ab", "abac", "abacus", "ABACUS", "abdomens", "fo", "foo", "foobaz", "moo", "zulus"}
+
+	trie := NewTrie(WithDisplayForms(AllForms), WithSuffixIndex())
+	expected := NewTrie(WithDisplayForms(AllForms), WithSuffixIndex())
+	for _, w := range existing {
+		trie.Insert(w)
+		expected.Insert(w)
+	}
+
+	before := trie.Snapshot()
+
+	if err := trie.InsertSorted(sorted); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, w := range sorted {
+		expected.Insert(w)
+	}
+
+	verifySameTree(t, trie.Snapshot().children, expected.Snapshot().children, "")
+
+	if trie.Count() != expected.Count() {
+		t.Errorf("trie should hold %v words; found %v", expected.Count(), trie.Count())
+	}
+
+	verifyOrderedMatches(t, trie.Like("ab", -1), expected.Like("ab", -1)...)
+	verifyOrderedMatches(t, trie.EndsWith("s", -1), expected.EndsWith("s", -1)...)
+	verifyOrderedMatches(t, before.Like("a", -1), "abacus", "abdomen")
+}
+
+func BenchmarkInsertSortedIntoExistingWords(b *testing.B) {
+
+	words := slices.Sorted(slices.Values(benchmarkWords()))
+
+	for i := 0; i < b.N; i++ {
+		trie := NewTrie()
+		trie.Insert("existing")
+		trie.InsertSorted(words)
+	}
+}
+
+func BenchmarkInsertIntoExistingWords(b *testing.B) {
+
+	words := slices.Sorted(slices.Values(benchmarkWords()))
+
+	for i := 0; i < b.N; i++ {
+		trie := NewTrie()
+		trie.Insert("existing")
+		for _, w := range words {
+			trie.Insert(w)
+		}
+	}
+}
+
+// verifySameTree checks that two trees have the same shape and words
+func verifySameTree(t *testing.T, actual []*node, expected []*node, prefix string) {
+
+	if len(actual) != len(expected) {
+		t.Fatalf("[%v] node should have %v children; found %v", prefix, len(expected), len(actual))
+	}
+
+	for i := range expected {
+
+		a, e := actual[i], expected[i]
+		if string(a.value) != string(e.value) || a.endOfWord != e.endOfWord {
+			t.Fatalf("[%v] child %v should be '%v'; found '%v'", prefix, i, string(e.value), string(a.value))
+		}
+
+		verifySameTree(t, a.children, e.children, prefix+string(e.value))
+	}
+}
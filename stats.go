@@ -0,0 +1,108 @@
+package trie
+
+import "unsafe"
+
+// Stats describes the shape of a Trie and roughly how much memory it uses
+type Stats struct {
+
+	// Nodes is the number of nodes in the tree, and Words is how many of them end a word
+	Nodes int
+	Words int
+
+	// MaxDepth is the most nodes on the path from the root to a word, and AvgDepth is the average over every word
+	MaxDepth int
+	AvgDepth float64
+
+	// Branching counts the nodes by how many children they have, so Branching[2] is the number of nodes with
+	// two children. The root is not counted.
+	Branching []int
+
+	// ChildrenLen and ChildrenCap are the total length and capacity of the slices holding children, including
+	// the root's. The difference is the room left by append growing the slices.
+	ChildrenLen int
+	ChildrenCap int
+
+	// Bytes estimates the memory held by the tree, and IndexBytes the memory held by any indexes the Trie keeps
+	Bytes      int64
+	IndexBytes int64
+}
+
+// Stats returns the shape and estimated size of the Trie as it is now
+func (t *Trie) Stats() Stats {
+	return t.Snapshot().Stats()
+}
+
+// Stats returns the shape and estimated size of the Snapshot
+func (s *Snapshot) Stats() Stats {
+
+	stats := Stats{Branching: make([]int, 0)}
+
+	// depths is the sum of the depths of every word, for the average
+	var depths int
+
+	var walk func(children []*node, depth int) int64
+	walk = func(children []*node, depth int) int64 {
+
+		stats.ChildrenLen += len(children)
+		stats.ChildrenCap += cap(children)
+
+		bytes := int64(cap(children)) * int64(unsafe.Sizeof((*node)(nil)))
+
+		for _, n := range children {
+
+			stats.Nodes++
+			if n.endOfWord {
+				stats.Words++
+				stats.MaxDepth = max(stats.MaxDepth, depth+1)
+				depths += depth + 1
+			}
+
+			for len(stats.Branching) <= len(n.children) {
+				stats.Branching = append(stats.Branching, 0)
+			}
+			stats.Branching[len(n.children)]++
+
+			bytes += nodeBytes(n) + walk(n.children, depth+1)
+		}
+
+		return bytes
+	}
+
+	stats.Bytes = walk(s.children, 0)
+
+	if stats.Words > 0 {
+		stats.AvgDepth = float64(depths) / float64(stats.Words)
+	}
+
+	for _, index := range [][]*node{s.reversed, s.substrings, s.tokens} {
+		stats.IndexBytes += treeBytes(index)
+	}
+
+	return stats
+}
+
+// nodeBytes estimates the memory held by the node itself, apart from its children
+func nodeBytes(n *node) int64 {
+
+	bytes := int64(unsafe.Sizeof(*n)) + int64(cap(n.value))*int64(unsafe.Sizeof(rune(0)))
+
+	if n.payload != nil {
+		bytes += int64(unsafe.Sizeof(*n.payload)) + int64(cap(n.payload.forms))*int64(unsafe.Sizeof(displayForm{}))
+		for _, f := range n.payload.forms {
+			bytes += int64(len(f.text))
+		}
+	}
+
+	return bytes
+}
+
+// treeBytes estimates the memory held by the tree with the root children
+func treeBytes(children []*node) int64 {
+
+	bytes := int64(cap(children)) * int64(unsafe.Sizeof((*node)(nil)))
+	for _, n := range children {
+		bytes += nodeBytes(n) + treeBytes(n.children)
+	}
+
+	return bytes
+}
@@ -0,0 +1,63 @@
+package trie
+
+import "testing"
+
+func TestStatsOfEmptyTrie(t *testing.T) {
+
+	stats := NewTrie().Stats()
+
+	if stats.Nodes != 0 || stats.Words != 0 || stats.MaxDepth != 0 || stats.AvgDepth != 0 || stats.Bytes != 0 {
+		t.Errorf("empty trie should have no nodes; found %+v", stats)
+	}
+}
+
+func TestStats(t *testing.T) {
+
+	trie := NewTrie()
+	trie.Insert("crazy")
+	trie.Insert("crayon")
+	trie.Insert("cream")
+	trie.Insert("cr")
+
+	// cr -> a -> yon, zy
+	//    -> eam
+	stats := trie.Stats()
+
+	if stats.Nodes != 5 || stats.Words != 4 {
+		t.Errorf("trie should have 5 nodes and 4 words; found %v and %v", stats.Nodes, stats.Words)
+	}
+
+	if stats.MaxDepth != 3 || stats.AvgDepth != 9.0/4 {
+		t.Errorf("trie should have a max depth of 3 and an average of 2.25; found %v and %v", stats.MaxDepth, stats.AvgDepth)
+	}
+
+	expected := []int{3, 0, 2}
+	if len(stats.Branching) != len(expected) {
+		t.Fatalf("branching should be %v; found %v", expected, stats.Branching)
+	}
+
+	for i, b := range expected {
+		if stats.Branching[i] != b {
+			t.Errorf("branching should be %v; found %v", expected, stats.Branching)
+		}
+	}
+
+	if stats.ChildrenLen != 5 || stats.ChildrenCap < stats.ChildrenLen {
+		t.Errorf("children should have a length of 5 and at least that capacity; found %v and %v", stats.ChildrenLen, stats.ChildrenCap)
+	}
+
+	if stats.Bytes <= 0 || stats.IndexBytes != 0 {
+		t.Errorf("trie should hold some bytes and no index; found %v and %v", stats.Bytes, stats.IndexBytes)
+	}
+}
+
+func TestStatsCountsIndexes(t *testing.T) {
+
+	trie := NewTrie(WithSubstringIndex())
+	trie.Insert("banana")
+
+	stats := trie.Stats()
+	if stats.Nodes != 1 || stats.IndexBytes <= stats.Bytes {
+		t.Errorf("index should hold more bytes than the single node; found %+v", stats)
+	}
+}
@@ -0,0 +1,127 @@
+package trie
+
+import "sort"
+
+// WithSubstringIndex keeps a tree of every suffix of every word, so words can be found by any part of them with
+// Substring. The index grows with the square of the length of each word, so it is best kept to short words.
+func WithSubstringIndex() Option {
+	return func(t *Trie) { t.substringIndex = true }
+}
+
+// Substring will find the words that contain the fragment anywhere in them in alphabetical order, up to the
+// supplied count. It never finds any words unless the Trie was created with WithSubstringIndex.
+func (t *Trie) Substring(fragment string, count int) []string {
+	return t.Snapshot().Substring(fragment, count)
+}
+
+// Substring finds the words in the Snapshot that contain the fragment, as Trie.Substring does
+func (s *Snapshot) Substring(fragment string, count int) []string {
+
+	if len(fragment) == 0 || !s.substringIndex {
+		return make([]string, 0)
+	}
+
+	return s.displayWords(owners(s.substrings, s.split(fragment), count), count)
+}
+
+// wordSet is a set of words, used to gather the owners of the keys in an index
+type wordSet map[string]struct{}
+
+// ownerMark separates a key in an index from the word that owns it. No word can
+// hold it, as every rune decoded from a string is a valid code point.
+const ownerMark rune = -1
+
+// indexSuffixes adds every suffix of the word to the index, each owned by the word
+func indexSuffixes(index []*node, word []rune) []*node {
+	for i := range word {
+		index = indexKey(index, word[i:], word)
+	}
+
+	return index
+}
+
+// unindexSuffixes removes the word from every one of its suffixes in the index
+func unindexSuffixes(index []*node, word []rune) []*node {
+	for i := range word {
+		index = unindexKey(index, word[i:], word)
+	}
+
+	return index
+}
+
+// indexKey adds the key to the index, owned by the word. The owner is stored at
+// the end of the key, so that adding an owner never changes a node in place.
+func indexKey(index []*node, key []rune, word []rune) []*node {
+
+	index, _, _ = insert(index, ownedKey(key, word))
+
+	return index
+}
+
+// unindexKey removes the word from the owners of the key in the index
+func unindexKey(index []*node, key []rune, word []rune) []*node {
+
+	index, _ = remove(index, ownedKey(key, word))
+
+	return index
+}
+
+// ownedKey returns the key followed by the ownerMark and the word that owns it
+func ownedKey(key []rune, word []rune) []rune {
+
+	owned := make([]rune, 0, len(key)+1+len(word))
+	owned = append(append(owned, key...), ownerMark)
+
+	return append(owned, word...)
+}
+
+// owners returns the words that own any key in the index starting with the prefix
+func owners(index []*node, prefix []rune, count int) []string {
+	return sortedWords(ownerSet(index, prefix), count)
+}
+
+// ownerSet returns the set of words that own any key in the index starting with the prefix
+func ownerSet(index []*node, prefix []rune) wordSet {
+
+	// the same word can own many keys, so the sets are merged before sorting
+	seen := make(wordSet)
+
+	endOfPrefix, key := complete(index, prefix)
+	if endOfPrefix == nil {
+		return seen
+	}
+
+	add := func(owned []rune, _ *node) bool {
+		for i, r := range owned {
+			if r == ownerMark {
+				seen[string(owned[i+1:])] = struct{}{}
+				break
+			}
+		}
+		return true
+	}
+
+	if endOfPrefix.endOfWord {
+		add(key, endOfPrefix)
+	}
+	walk(endOfPrefix, key, add)
+
+	return seen
+}
+
+// sortedWords returns the words in the set in alphabetical order, up to the supplied count
+func sortedWords(set wordSet, count int) []string {
+
+	words := make([]string, 0, len(set))
+	for w := range set {
+		words = append(words, w)
+	}
+
+	sort.Strings(words)
+
+	if count >= 0 && len(words) > count {
+		words = words[:count]
+	}
+
+	return words
+}
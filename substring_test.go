@@ -0,0 +1,72 @@
+package trie
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestSubstringWithoutIndex(t *testing.T) {
+
+	trie := NewTrie()
+	trie.Insert("abdomen")
+
+	verifyMatches(t, trie.Substring("dom", -1))
+}
+
+func TestSubstring(t *testing.T) {
+
+	trie := NewTrie(WithSubstringIndex())
+	for _, w := range wordsLike {
+		trie.Insert(w)
+	}
+
+	verifyOrderedMatches(t, trie.Substring("dom", -1), "abdomen", "abdominal", "abdominocentesis")
+	verifyOrderedMatches(t, trie.Substring("DOM", 2), "abdomen", "abdominal")
+	verifyOrderedMatches(t, trie.Substring("aachen", -1), "aachen")
+	verifyOrderedMatches(t, trie.Substring("ee", -1), "abandonee", "abductee")
+	verifyMatches(t, trie.Substring("xyz", -1))
+	verifyMatches(t, trie.Substring("", -1))
+	verifyMatches(t, trie.Substring("dom", 0))
+}
+
+func TestSubstringDoesNotRepeatWords(t *testing.T) {
+
+	trie := NewTrie(WithSubstringIndex())
+	trie.Insert("banana")
+	trie.Insert("bandana")
+
+	verifyOrderedMatches(t, trie.Substring("an", -1), "banana", "bandana")
+	verifyOrderedMatches(t, trie.Substring("a", -1), "banana", "bandana")
+}
+
+func TestSubstringAfterRemove(t *testing.T) {
+
+	trie := NewTrie(WithSubstringIndex())
+	trie.Insert("banana")
+	trie.Insert("bandana")
+	trie.Insert("ban")
+	trie.Remove("banana")
+
+	verifyOrderedMatches(t, trie.Substring("an", -1), "ban", "bandana")
+	verifyOrderedMatches(t, trie.Substring("nan", -1))
+
+	trie.Remove("bandana")
+	trie.Remove("ban")
+
+	if len(trie.Snapshot().substrings) != 0 {
+		t.Errorf("index should be empty once every word is removed; found %v keys", len(trie.Snapshot().substrings))
+	}
+}
+
+func TestSubstringAfterUnmarshal(t *testing.T) {
+
+	trie := NewTrie(WithSubstringIndex())
+	trie.Insert("replaced")
+
+	if err := json.Unmarshal([]byte(`["abdomen","abdominal"]`), trie); err != nil {
+		t.Fatal(err)
+	}
+
+	verifyOrderedMatches(t, trie.Substring("dom", -1), "abdomen", "abdominal")
+	verifyMatches(t, trie.Substring("place", -1))
+}
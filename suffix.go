@@ -0,0 +1,55 @@
+package trie
+
+import "sort"
+
+// WithSuffixIndex keeps a second tree of every word spelled backwards, so words can be found by how they end
+// with EndsWith. The index roughly doubles the memory used by the Trie.
+func WithSuffixIndex() Option {
+	return func(t *Trie) { t.suffixIndex = true }
+}
+
+// EndsWith will find the words that end with the suffix in alphabetical order, up to the supplied count. It
+// never finds any words unless the Trie was created with WithSuffixIndex.
+func (t *Trie) EndsWith(suffix string, count int) []string {
+	return t.Snapshot().EndsWith(suffix, count)
+}
+
+// EndsWith finds the words in the Snapshot that end with the suffix, as Trie.EndsWith does
+func (s *Snapshot) EndsWith(suffix string, count int) []string {
+
+	if len(suffix) == 0 || !s.suffixIndex {
+		return make([]string, 0)
+	}
+
+	return s.displayWords(endsWith(s.reversed, reverseWord(s.split(suffix)), count), count)
+}
+
+func endsWith(reversed []*node, reversedSuffix []rune, count int) []string {
+
+	words := make([]string, 0)
+
+	// the reversed tree holds the words in order of how they end, so every match
+	// is needed before the first few can be picked alphabetically
+	for _, w := range like(reversed, reversedSuffix, -1) {
+		words = append(words, string(reverseWord([]rune(w))))
+	}
+
+	sort.Strings(words)
+
+	if count >= 0 && len(words) > count {
+		words = words[:count]
+	}
+
+	return words
+}
+
+// reverseWord returns a copy of the word with its runes in reverse order
+func reverseWord(word []rune) []rune {
+
+	reversed := make([]rune, len(word))
+	for i, r := range word {
+		reversed[len(word)-1-i] = r
+	}
+
+	return reversed
+}
@@ -0,0 +1,64 @@
+package trie
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestEndsWithWithoutIndex(t *testing.T) {
+
+	trie := NewTrie()
+	trie.Insert("abductor")
+
+	verifyMatches(t, trie.EndsWith("ductor", -1))
+}
+
+func TestEndsWith(t *testing.T) {
+
+	trie := NewTrie(WithSuffixIndex())
+	for _, w := range wordsLike {
+		trie.Insert(w)
+	}
+	trie.Insert("conductor")
+	trie.Insert("Ductor")
+
+	verifyOrderedMatches(t, trie.EndsWith("ductor", -1), "abductor", "conductor", "ductor")
+	verifyOrderedMatches(t, trie.EndsWith("TOR", 3), "abator", "abbreviator", "abdicator")
+	verifyMatches(t, trie.EndsWith("xyz", -1))
+	verifyMatches(t, trie.EndsWith("", -1))
+	verifyMatches(t, trie.EndsWith("tor", 0))
+}
+
+func TestEndsWithAfterRemove(t *testing.T) {
+
+	trie := NewTrie(WithSuffixIndex())
+	trie.Insert("abductor")
+	trie.Insert("conductor")
+	trie.Insert("conductor")
+	trie.Remove("abductor")
+	trie.Remove("missing")
+
+	verifyOrderedMatches(t, trie.EndsWith("ductor", -1), "conductor")
+
+	trie.Remove("conductor")
+	verifyMatches(t, trie.EndsWith("r", -1))
+}
+
+func TestEndsWithAfterReadFrom(t *testing.T) {
+
+	trie := NewTrie()
+	trie.Insert("abductor")
+	trie.Insert("conductor")
+
+	var buf bytes.Buffer
+	trie.WriteTo(&buf)
+
+	loaded := NewTrie(WithSuffixIndex())
+	loaded.Insert("replaced")
+	if _, err := loaded.ReadFrom(&buf); err != nil {
+		t.Fatal(err)
+	}
+
+	verifyOrderedMatches(t, loaded.EndsWith("ductor", -1), "abductor", "conductor")
+	verifyMatches(t, loaded.EndsWith("replaced", -1))
+}
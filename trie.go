@@ -3,29 +3,90 @@ package trie
 import (
 	"strings"
 	"sync"
+	"sync/atomic"
 )
 
 // Trie is a data structure that is optimized for storing and searching strings,
-// as well as string matching based on a prefix
+// as well as string matching based on a prefix.
+//
+// Every change to a Trie makes a new Snapshot that shares all of the unchanged
+// nodes with the one before it, and swaps it in as the latest. Searches read the
+// latest Snapshot without taking a lock, so they never wait for writers, and
+// writers never wait for searches. Writers still wait for each other.
 type Trie struct {
+	settings
+
+	current atomic.Pointer[Snapshot]
+	lock    sync.Mutex
+}
+
+// settings are the options a Trie was created with, which every Snapshot of it shares
+type settings struct {
+
+	// normalizer turns words into the form they are stored in, or Lowercase when nil
+	normalizer Normalizer
+
+	// display picks the spellings that are returned, when they are being kept
+	display DisplayPolicy
+
+	// suffixIndex keeps every word with its runes reversed
+	suffixIndex bool
+
+	// substringIndex keeps every suffix of every word
+	substringIndex bool
+
+	// phraseIndex keeps every token of every word
+	phraseIndex bool
+}
+
+// Snapshot is the contents of a Trie at a moment in time. A Snapshot never
+// changes, so it is safe for concurrent use without any locking, and searching
+// it gives the same results however the Trie changes afterwards.
+type Snapshot struct {
+	*settings
+
 	count    int
 	children []*node
-	lock     sync.RWMutex
+	version  uint64
+
+	// reversed, substrings and tokens are the indexes kept when the settings ask for them
+	reversed   []*node
+	substrings []*node
+	tokens     []*node
+}
+
+// Option configures a Trie when it is created
+type Option func(*Trie)
+
+// NewTrie initializes the Trie with the supplied options
+func NewTrie(opts ...Option) *Trie {
+
+	t := &Trie{}
+	for _, opt := range opts {
+		opt(t)
+	}
+
+	return t
 }
 
-// NewTrie initializes the Trie
-func NewTrie() *Trie {
-	return &Trie{}
+// Snapshot returns the contents of the Trie as they are now
+func (t *Trie) Snapshot() *Snapshot {
+
+	if s := t.current.Load(); s != nil {
+		return s
+	}
+
+	return &Snapshot{settings: &t.settings}
 }
 
 // Count returns the number of unique words currently stored in the Trie
 func (t *Trie) Count() int {
+	return t.Snapshot().Count()
+}
 
-	t.lock.RLock()
-	c := t.count
-	t.lock.RUnlock()
-
-	return c
+// Count returns the number of unique words in the Snapshot
+func (s *Snapshot) Count() int {
+	return s.count
 }
 
 // Insert will insert a new word into the Trie.  If the word already exists it does not store a duplicate copy,
@@ -37,23 +98,41 @@ func (t *Trie) Insert(word string) {
 		return
 	}
 
-	t.lock.Lock()
-	if c, inserted := insert(t.children, splitWord(word), nil); inserted {
-		t.children = c
-		t.count++
+	t.update(func(s *Snapshot) {
+		s.add(s.split(word), word)
+	})
+}
+
+// InsertWeighted will insert a word into the Trie with a score used to rank it in LikeTopK. If the word already
+// exists only its score is replaced. Words added with Insert have a score of zero.
+func (t *Trie) InsertWeighted(word string, score float64) {
+
+	// If word has a zero length, do nothing
+	if len(word) == 0 {
+		return
 	}
-	t.lock.Unlock()
+
+	runes := t.split(word)
+
+	t.update(func(s *Snapshot) {
+		if n, _ := s.add(runes, word); n != nil {
+			n.edit().score = score
+		}
+	})
 }
 
 // Contains will check the Trie to see if a word is currently stored.
 func (t *Trie) Contains(word string) bool {
+	return t.Snapshot().Contains(word)
+}
+
+// Contains reports whether the word is in the Snapshot
+func (s *Snapshot) Contains(word string) bool {
 	if len(word) == 0 {
 		return false
 	}
 
-	t.lock.RLock()
-	found, _ := contains(t.children, splitWord(word))
-	t.lock.RUnlock()
+	found, _ := contains(s.children, s.split(word))
 
 	return found
 }
@@ -64,26 +143,126 @@ func (t *Trie) Remove(word string) {
 		return
 	}
 
-	t.lock.Lock()
-	if c, removed := remove(t.children, splitWord(word)); removed {
-		t.children = c
-		t.count--
-	}
-	t.lock.Unlock()
+	t.update(func(s *Snapshot) {
+		s.delete(s.split(word))
+	})
 }
 
 // Like will traverse the Trie and find the best matches. up to the supplied count
 func (t *Trie) Like(prefix string, count int) []string {
+	return t.Snapshot().Like(prefix, count)
+}
+
+// Like finds the words in the Snapshot that start with the prefix, as Trie.Like does
+func (s *Snapshot) Like(prefix string, count int) []string {
 
 	if len(prefix) == 0 {
 		return make([]string, 0)
 	}
 
-	t.lock.RLock()
-	words := like(t.children, splitWord(prefix), count)
-	t.lock.RUnlock()
+	return s.displayWords(like(s.children, s.split(prefix), count), count)
+}
+
+// update makes the change to a copy of the latest Snapshot, and swaps the copy
+// in once it is done. Writers take turns, so no change is lost.
+func (t *Trie) update(change func(*Snapshot)) {
 
-	return words
+	t.lock.Lock()
+	next := *t.Snapshot()
+	change(&next)
+	t.current.Store(&next)
+	t.lock.Unlock()
+}
+
+// add inserts the word into the tree and any indexes, returning the node the
+// word ends on and whether the word was not already there. text is the word as
+// it was inserted, before it was normalized. The Snapshot must not have been
+// shared yet, and the node may be changed until it is.
+func (s *Snapshot) add(word []rune, text string) (*node, bool) {
+
+	c, n, inserted := insert(s.children, word)
+	if n == nil {
+		return nil, false
+	}
+
+	s.children = c
+
+	if s.display != 0 {
+		n.addForm(text)
+	}
+
+	if inserted {
+		s.count++
+		s.version++
+		s.index(word)
+	}
+
+	return n, inserted
+}
+
+// delete removes the word from the tree and any indexes, and reports whether
+// it was there. The Snapshot must not have been shared yet.
+func (s *Snapshot) delete(word []rune) bool {
+
+	c, removed := remove(s.children, word)
+	if !removed {
+		return false
+	}
+
+	s.children = c
+	s.count--
+	s.version++
+	s.unindex(word)
+
+	return true
+}
+
+// index adds a new word to every index the Snapshot keeps
+func (s *Snapshot) index(word []rune) {
+
+	if s.suffixIndex {
+		s.reversed, _, _ = insert(s.reversed, reverseWord(word))
+	}
+
+	if s.substringIndex {
+		s.substrings = indexSuffixes(s.substrings, word)
+	}
+
+	if s.phraseIndex {
+		s.tokens = indexTokens(s.tokens, word)
+	}
+}
+
+// unindex removes a word from every index the Snapshot keeps
+func (s *Snapshot) unindex(word []rune) {
+
+	if s.suffixIndex {
+		s.reversed, _ = remove(s.reversed, reverseWord(word))
+	}
+
+	if s.substringIndex {
+		s.substrings = unindexSuffixes(s.substrings, word)
+	}
+
+	if s.phraseIndex {
+		s.tokens = unindexTokens(s.tokens, word)
+	}
+}
+
+// swap replaces every word in the Trie with the tree that has the root children,
+// building the indexes for the new words before any reader can see them
+func (t *Trie) swap(count int, children []*node) {
+
+	fresh := &Snapshot{settings: &t.settings, count: count, children: children}
+	walkAll(children, func(word []rune, _ *node) bool {
+		fresh.index(word)
+		return true
+	})
+
+	t.lock.Lock()
+	fresh.version = t.Snapshot().version + 1
+	t.current.Store(fresh)
+	t.lock.Unlock()
 }
 
 func splitWord(word string) []rune {
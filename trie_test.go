@@ -3,7 +3,9 @@ package trie
 import (
 	"bufio"
 	"log"
+	"math/rand"
 	"os"
+	"sort"
 	"sync"
 	"testing"
 )
@@ -229,6 +231,41 @@ func TestConcurrentInserts(t *testing.T) {
 	}
 }
 
+func TestRandomInsertsAndRemoves(t *testing.T) {
+
+	trie := NewTrie()
+	expected := make(map[string]bool)
+	r := rand.New(rand.NewSource(1))
+
+	for i := 0; i < 20000; i++ {
+		word := make([]rune, 1+r.Intn(6))
+		for j := range word {
+			word[j] = 'a' + rune(r.Intn(3))
+		}
+
+		if w := string(word); r.Intn(3) == 0 {
+			trie.Remove(w)
+			delete(expected, w)
+		} else {
+			trie.Insert(w)
+			expected[w] = true
+		}
+	}
+
+	words := make([]string, 0, len(expected))
+	for w := range expected {
+		words = append(words, w)
+	}
+	sort.Strings(words)
+
+	if trie.Count() != len(words) {
+		t.Errorf("Trie should contain %v words but found %v", len(words), trie.Count())
+	}
+
+	all := append(trie.Like("a", -1), trie.Like("b", -1)...)
+	verifyOrderedMatches(t, append(all, trie.Like("c", -1)...), words...)
+}
+
 func TestWordsLikeEmptyWord(t *testing.T) {
 
 	trie := NewTrie()
@@ -326,3 +363,13 @@ func readWordFile(file string) []string {
 
 	return words
 }
+
+func verifyOrderedMatches(t *testing.T, actual []string, expected ...string) {
+	verifyMatches(t, actual, expected...)
+
+	for i, w := range expected {
+		if actual[i] != w {
+			t.Errorf("match %v should be %v but found %v", i, w, actual[i])
+		}
+	}
+}